@@ -0,0 +1,262 @@
+/*
+Copyright (c) 2023 Infisical Inc.
+*/
+package cmd
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/Infisical/infisical-merge/packages/models"
+	"github.com/Infisical/infisical-merge/packages/util"
+	"github.com/Infisical/infisical-merge/packages/visualize"
+	"github.com/spf13/cobra"
+)
+
+// SecretDiffRow is a single folder+KEY's comparison between environment/file
+// A and B. Path is only meaningful when --recursive is used; a plain,
+// non-recursive diff always has Path equal to the --path that was compared.
+type SecretDiffRow struct {
+	Path   string `json:"path"`
+	Key    string `json:"key"`
+	ValueA string `json:"valueA"`
+	ValueB string `json:"valueB"`
+	Status string `json:"status"`
+}
+
+var secretsDiffCmd = &cobra.Command{
+	Example:               `secrets diff dev prod`,
+	Short:                 "Used to compare secrets between two environments, or a local file and an environment",
+	Use:                   "diff [environment A] [environment B]",
+	DisableFlagsInUseLine: true,
+	PreRun:                toggleDebug,
+	Args:                  cobra.RangeArgs(1, 2),
+	Run: func(cmd *cobra.Command, args []string) {
+		secretsPath, err := cmd.Flags().GetString("path")
+		if err != nil {
+			util.HandleError(err, "Unable to parse path flag")
+		}
+
+		shouldRecurse, err := cmd.Flags().GetBool("recursive")
+		if err != nil {
+			util.HandleError(err, "Unable to parse recursive flag")
+		}
+
+		file, err := cmd.Flags().GetString("file")
+		if err != nil {
+			util.HandleError(err, "Unable to parse file flag")
+		}
+
+		output, err := cmd.Flags().GetString("output")
+		if err != nil {
+			util.HandleError(err, "Unable to parse output flag")
+		}
+
+		shouldPromote, err := cmd.Flags().GetBool("promote")
+		if err != nil {
+			util.HandleError(err, "Unable to parse promote flag")
+		}
+
+		var labelA, labelB string
+		var secretsA, secretsB []models.SingleEnvironmentVariable
+
+		if file != "" {
+			if len(args) != 1 {
+				util.PrintMessageAndExit("specify exactly one environment to diff --file against, e.g. [infisical secrets diff --file .env prod]")
+			}
+
+			keyValuePairs, err := parseSecretsFile(file)
+			if err != nil {
+				util.HandleError(err, "Unable to parse the file you are attempting to diff")
+			}
+
+			labelA = file
+			for key, value := range keyValuePairs {
+				secretsA = append(secretsA, models.SingleEnvironmentVariable{Key: strings.ToUpper(key), Value: value, Path: secretsPath})
+			}
+
+			labelB = args[0]
+			secretsB, err = util.GetAllEnvironmentVariables(models.GetAllSecretsParameters{Environment: labelB, SecretsPath: secretsPath, Recursive: shouldRecurse})
+			if err != nil {
+				util.HandleError(err, "Unable to fetch secrets")
+			}
+		} else {
+			if len(args) != 2 {
+				util.PrintMessageAndExit("specify two environments to diff, e.g. [infisical secrets diff dev prod], or use --file to diff a local file against an environment")
+			}
+
+			labelA = args[0]
+			labelB = args[1]
+
+			secretsA, err = util.GetAllEnvironmentVariables(models.GetAllSecretsParameters{Environment: labelA, SecretsPath: secretsPath, Recursive: shouldRecurse})
+			if err != nil {
+				util.HandleError(err, "Unable to fetch secrets")
+			}
+
+			secretsB, err = util.GetAllEnvironmentVariables(models.GetAllSecretsParameters{Environment: labelB, SecretsPath: secretsPath, Recursive: shouldRecurse})
+			if err != nil {
+				util.HandleError(err, "Unable to fetch secrets")
+			}
+		}
+
+		secretsAByPathAndKey := getSecretsByPathAndKey(secretsA)
+		secretsBByPathAndKey := getSecretsByPathAndKey(secretsB)
+
+		rows, keysToPromote := computeSecretDiff(secretsAByPathAndKey, secretsBByPathAndKey)
+
+		switch output {
+		case "", "table":
+			headers := []string{"PATH", "KEY", labelA, labelB, "STATUS"}
+			tableRows := [][]string{}
+			for _, row := range rows {
+				tableRows = append(tableRows, []string{row.Path, row.Key, row.ValueA, row.ValueB, row.Status})
+			}
+			visualize.Table(headers, tableRows)
+		case "json":
+			marshalled, err := json.MarshalIndent(rows, "", "  ")
+			if err != nil {
+				util.HandleError(err, "Unable to render diff as json")
+			}
+			fmt.Println(string(marshalled))
+		default:
+			util.PrintMessageAndExit(fmt.Sprintf("unsupported --output %q, expected table or json", output))
+		}
+
+		if !shouldPromote {
+			return
+		}
+
+		if file != "" {
+			util.PrintMessageAndExit("--promote cannot be used together with --file, review the diff and run [infisical secrets set] manually")
+		}
+
+		if !util.IsSecretEnvironmentValid(labelB) {
+			util.PrintMessageAndExit("You have entered a invalid environment name", "Environment names can only be prod, dev, test or staging")
+		}
+
+		if len(keysToPromote) == 0 {
+			fmt.Println("Nothing to promote, environments are already in sync")
+			return
+		}
+
+		// group the Path+KEY composites being promoted by folder, since a
+		// single secretsPath can no longer stand in for every folder a
+		// --recursive diff touched
+		setArgsByPath := make(map[string][]string)
+		promotedNames := make([]string, 0, len(keysToPromote))
+		for _, compositeKey := range keysToPromote {
+			path, key, _ := strings.Cut(compositeKey, pathKeySeparator)
+			setArgsByPath[path] = append(setArgsByPath[path], fmt.Sprintf("%s=%s", key, secretsAByPathAndKey[compositeKey].Value))
+
+			if path == secretsPath {
+				promotedNames = append(promotedNames, key)
+			} else {
+				promotedNames = append(promotedNames, fmt.Sprintf("%s (%s)", key, path))
+			}
+		}
+
+		fmt.Printf("About to copy %d secret(s) from [%s] to [%s]: %s\n", len(keysToPromote), labelA, labelB, strings.Join(promotedNames, ", "))
+		fmt.Print("Continue? (y/N): ")
+
+		reader := bufio.NewReader(os.Stdin)
+		confirmation, _ := reader.ReadString('\n')
+		if strings.ToLower(strings.TrimSpace(confirmation)) != "y" {
+			fmt.Println("Promote aborted")
+			return
+		}
+
+		promotePaths := make([]string, 0, len(setArgsByPath))
+		for path := range setArgsByPath {
+			promotePaths = append(promotePaths, path)
+		}
+		sort.Strings(promotePaths)
+
+		for _, path := range promotePaths {
+			// each call already targets the exact folder the diff matched,
+			// so there's no subtree left to search
+			setSecrets(labelB, path, false, setArgsByPath[path])
+		}
+	},
+}
+
+// pathKeySeparator joins a secret's Path and Key into the composite key used
+// to diff secrets without collapsing same-named secrets that live in
+// different folders under --recursive.
+const pathKeySeparator = "\x00"
+
+// getSecretsByPathAndKey maps secrets by Path+Key so that two secrets sharing
+// a KEY in different folders are compared independently instead of one
+// silently shadowing the other.
+func getSecretsByPathAndKey(secrets []models.SingleEnvironmentVariable) map[string]models.SingleEnvironmentVariable {
+	secretMapByPathAndKey := make(map[string]models.SingleEnvironmentVariable)
+
+	for _, secret := range secrets {
+		secretMapByPathAndKey[secret.Path+pathKeySeparator+secret.Key] = secret
+	}
+
+	return secretMapByPathAndKey
+}
+
+// computeSecretDiff compares two sets of secrets keyed by Path+KEY (see
+// getSecretsByPathAndKey) and returns a sorted row per folder+key found in
+// either set, along with the Path+KEY composites that would be promoted
+// (ONLY_A and DIFFERENT) by --promote.
+func computeSecretDiff(secretsAByPathAndKey map[string]models.SingleEnvironmentVariable, secretsBByPathAndKey map[string]models.SingleEnvironmentVariable) ([]SecretDiffRow, []string) {
+	allCompositeKeys := make(map[string]bool)
+	for compositeKey := range secretsAByPathAndKey {
+		allCompositeKeys[compositeKey] = true
+	}
+	for compositeKey := range secretsBByPathAndKey {
+		allCompositeKeys[compositeKey] = true
+	}
+
+	sortedCompositeKeys := make([]string, 0, len(allCompositeKeys))
+	for compositeKey := range allCompositeKeys {
+		sortedCompositeKeys = append(sortedCompositeKeys, compositeKey)
+	}
+	sort.Strings(sortedCompositeKeys)
+
+	rows := []SecretDiffRow{}
+	keysToPromote := []string{}
+
+	for _, compositeKey := range sortedCompositeKeys {
+		secretA, okA := secretsAByPathAndKey[compositeKey]
+		secretB, okB := secretsBByPathAndKey[compositeKey]
+
+		path, key, _ := strings.Cut(compositeKey, pathKeySeparator)
+
+		var status string
+		switch {
+		case okA && !okB:
+			status = "ONLY_A"
+			keysToPromote = append(keysToPromote, compositeKey)
+		case !okA && okB:
+			status = "ONLY_B"
+		case secretA.Value != secretB.Value:
+			status = "DIFFERENT"
+			keysToPromote = append(keysToPromote, compositeKey)
+		default:
+			status = "EQUAL"
+		}
+
+		rows = append(rows, SecretDiffRow{Path: path, Key: key, ValueA: secretA.Value, ValueB: secretB.Value, Status: status})
+	}
+
+	return rows, keysToPromote
+}
+
+func init() {
+	secretsDiffCmd.Flags().String("file", "", "Diff a local secrets file against an environment instead of two environments")
+	secretsDiffCmd.Flags().String("output", "table", "The format to print the diff in: table or json")
+	secretsDiffCmd.Flags().Bool("promote", false, "After confirmation, copy missing/changed keys from environment/file A into environment B")
+
+	secretsCmd.AddCommand(secretsDiffCmd)
+	secretsDiffCmd.PersistentPreRun = func(cmd *cobra.Command, args []string) {
+		util.RequireLogin()
+		util.RequireLocalWorkspaceFile()
+	}
+}
@@ -0,0 +1,118 @@
+/*
+Copyright (c) 2023 Infisical Inc.
+*/
+package cmd
+
+import (
+	"testing"
+
+	"github.com/Infisical/infisical-merge/packages/models"
+)
+
+func TestComputeSecretDiff(t *testing.T) {
+	secretsAByPathAndKey := map[string]models.SingleEnvironmentVariable{
+		"/\x00ONLY_IN_A": {Key: "ONLY_IN_A", Value: "a", Path: "/"},
+		"/\x00SAME":      {Key: "SAME", Value: "same", Path: "/"},
+		"/\x00DIFFERS":   {Key: "DIFFERS", Value: "a-value", Path: "/"},
+	}
+	secretsBByPathAndKey := map[string]models.SingleEnvironmentVariable{
+		"/\x00ONLY_IN_B": {Key: "ONLY_IN_B", Value: "b", Path: "/"},
+		"/\x00SAME":      {Key: "SAME", Value: "same", Path: "/"},
+		"/\x00DIFFERS":   {Key: "DIFFERS", Value: "b-value", Path: "/"},
+	}
+
+	rows, keysToPromote := computeSecretDiff(secretsAByPathAndKey, secretsBByPathAndKey)
+
+	statusByKey := make(map[string]string)
+	for _, row := range rows {
+		statusByKey[row.Key] = row.Status
+	}
+
+	wantStatus := map[string]string{
+		"ONLY_IN_A": "ONLY_A",
+		"ONLY_IN_B": "ONLY_B",
+		"SAME":      "EQUAL",
+		"DIFFERS":   "DIFFERENT",
+	}
+	for key, want := range wantStatus {
+		if statusByKey[key] != want {
+			t.Errorf("computeSecretDiff() status[%q] = %q, want %q", key, statusByKey[key], want)
+		}
+	}
+
+	if len(rows) != len(wantStatus) {
+		t.Errorf("computeSecretDiff() returned %d rows, want %d", len(rows), len(wantStatus))
+	}
+
+	gotPromote := make(map[string]bool)
+	for _, compositeKey := range keysToPromote {
+		gotPromote[compositeKey] = true
+	}
+	wantPromote := map[string]bool{"/\x00ONLY_IN_A": true, "/\x00DIFFERS": true}
+	if len(gotPromote) != len(wantPromote) {
+		t.Errorf("computeSecretDiff() keysToPromote = %v, want %v", keysToPromote, wantPromote)
+	}
+	for key := range wantPromote {
+		if !gotPromote[key] {
+			t.Errorf("computeSecretDiff() keysToPromote missing %q", key)
+		}
+	}
+	if gotPromote["/\x00ONLY_IN_B"] || gotPromote["/\x00SAME"] {
+		t.Errorf("computeSecretDiff() keysToPromote should not include ONLY_B or unchanged keys: %v", keysToPromote)
+	}
+}
+
+func TestComputeSecretDiffRowsAreSortedByPathThenKey(t *testing.T) {
+	secretsAByPathAndKey := map[string]models.SingleEnvironmentVariable{
+		"/\x00ZEBRA": {Key: "ZEBRA", Value: "1", Path: "/"},
+		"/\x00APPLE": {Key: "APPLE", Value: "2", Path: "/"},
+	}
+
+	rows, _ := computeSecretDiff(secretsAByPathAndKey, map[string]models.SingleEnvironmentVariable{})
+
+	if len(rows) != 2 || rows[0].Key != "APPLE" || rows[1].Key != "ZEBRA" {
+		t.Errorf("computeSecretDiff() rows = %v, want sorted by key", rows)
+	}
+}
+
+func TestComputeSecretDiffKeepsSameKeyInDifferentFoldersDistinct(t *testing.T) {
+	secretsAByPathAndKey := map[string]models.SingleEnvironmentVariable{
+		"/dev\x00DB_PASSWORD":  {Key: "DB_PASSWORD", Value: "dev-value", Path: "/dev"},
+		"/prod\x00DB_PASSWORD": {Key: "DB_PASSWORD", Value: "prod-value", Path: "/prod"},
+	}
+	secretsBByPathAndKey := map[string]models.SingleEnvironmentVariable{
+		"/dev\x00DB_PASSWORD": {Key: "DB_PASSWORD", Value: "dev-value", Path: "/dev"},
+	}
+
+	rows, keysToPromote := computeSecretDiff(secretsAByPathAndKey, secretsBByPathAndKey)
+
+	if len(rows) != 2 {
+		t.Fatalf("computeSecretDiff() returned %d rows, want 2, one per folder: %v", len(rows), rows)
+	}
+
+	statusByPath := make(map[string]string)
+	for _, row := range rows {
+		statusByPath[row.Path] = row.Status
+	}
+	if statusByPath["/dev"] != "EQUAL" {
+		t.Errorf("computeSecretDiff() status for /dev = %q, want EQUAL", statusByPath["/dev"])
+	}
+	if statusByPath["/prod"] != "ONLY_A" {
+		t.Errorf("computeSecretDiff() status for /prod = %q, want ONLY_A", statusByPath["/prod"])
+	}
+
+	if len(keysToPromote) != 1 || keysToPromote[0] != "/prod\x00DB_PASSWORD" {
+		t.Errorf("computeSecretDiff() keysToPromote = %v, want only the /prod copy", keysToPromote)
+	}
+}
+
+func TestComputeSecretDiffEmptyInputs(t *testing.T) {
+	rows, keysToPromote := computeSecretDiff(map[string]models.SingleEnvironmentVariable{}, map[string]models.SingleEnvironmentVariable{})
+
+	if len(rows) != 0 {
+		t.Errorf("computeSecretDiff() rows = %v, want none", rows)
+	}
+	if len(keysToPromote) != 0 {
+		t.Errorf("computeSecretDiff() keysToPromote = %v, want none", keysToPromote)
+	}
+}
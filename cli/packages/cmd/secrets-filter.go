@@ -0,0 +1,128 @@
+/*
+Copyright (c) 2023 Infisical Inc.
+*/
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/Infisical/infisical-merge/packages/models"
+	"github.com/Infisical/infisical-merge/packages/util"
+	"github.com/Infisical/infisical-merge/packages/visualize"
+	"gopkg.in/yaml.v2"
+)
+
+// filterSecrets narrows down secrets by tag (matched against tag slug or
+// name) and/or a "field=REGEX" filter expression matched against the
+// secret's key or value, e.g. "key=^DB_.*". Either narrowing step is skipped
+// when its corresponding argument is empty.
+func filterSecrets(secrets []models.SingleEnvironmentVariable, tagsCSV string, filterExpr string) ([]models.SingleEnvironmentVariable, error) {
+	filtered := secrets
+
+	if tagsCSV != "" {
+		wantedTags := make(map[string]bool)
+		for _, tag := range strings.Split(tagsCSV, ",") {
+			wantedTags[strings.TrimSpace(tag)] = true
+		}
+
+		tagFiltered := []models.SingleEnvironmentVariable{}
+		for _, secret := range filtered {
+			for _, tag := range secret.Tags {
+				if wantedTags[tag.Slug] || wantedTags[tag.Name] {
+					tagFiltered = append(tagFiltered, secret)
+					break
+				}
+			}
+		}
+		filtered = tagFiltered
+	}
+
+	if filterExpr != "" {
+		splitFilter := strings.SplitN(filterExpr, "=", 2)
+		if len(splitFilter) != 2 {
+			return nil, fmt.Errorf("--filter must be of the form field=REGEX, e.g. key=^DB_.*")
+		}
+
+		field := strings.ToLower(splitFilter[0])
+		pattern, err := regexp.Compile(splitFilter[1])
+		if err != nil {
+			return nil, err
+		}
+
+		patternFiltered := []models.SingleEnvironmentVariable{}
+		for _, secret := range filtered {
+			var target string
+			switch field {
+			case "key":
+				target = secret.Key
+			case "value":
+				target = secret.Value
+			default:
+				return nil, fmt.Errorf("unsupported --filter field %q, expected key or value", field)
+			}
+
+			if pattern.MatchString(target) {
+				patternFiltered = append(patternFiltered, secret)
+			}
+		}
+		filtered = patternFiltered
+	}
+
+	return filtered, nil
+}
+
+// printSecretsWithOutput renders secrets using the format requested via
+// --output, falling back to the existing table visualization when unset.
+func printSecretsWithOutput(secrets []models.SingleEnvironmentVariable, output string) {
+	switch output {
+	case "", "table":
+		visualize.PrintAllSecretDetails(secrets)
+
+	case "json":
+		marshalled, err := json.MarshalIndent(secretsToDisplayMap(secrets), "", "  ")
+		if err != nil {
+			util.HandleError(err, "Unable to render secrets as json")
+		}
+		fmt.Println(string(marshalled))
+
+	case "yaml":
+		marshalled, err := yaml.Marshal(secretsToDisplayMap(secrets))
+		if err != nil {
+			util.HandleError(err, "Unable to render secrets as yaml")
+		}
+		fmt.Print(string(marshalled))
+
+	case "dotenv":
+		for _, secret := range secrets {
+			fmt.Printf("%s=%s\n", secret.Key, secret.Value)
+		}
+
+	default:
+		util.PrintMessageAndExit(fmt.Sprintf("unsupported --output %q, expected one of: table, json, yaml, dotenv", output))
+	}
+}
+
+// secretsToDisplayMap renders secrets as a flat key/value map for the json
+// and yaml output formats, only qualifying a key with its folder (see
+// formatSecretDisplayName) when --recursive surfaced that same key in more
+// than one folder - otherwise one copy would silently overwrite the other
+// in the map with no warning.
+func secretsToDisplayMap(secrets []models.SingleEnvironmentVariable) map[string]string {
+	secretsByKey := getSecretsByKeys(secrets)
+
+	displayMap := make(map[string]string, len(secrets))
+	for _, matches := range secretsByKey {
+		if len(matches) == 1 {
+			displayMap[matches[0].Key] = matches[0].Value
+			continue
+		}
+		for _, secret := range matches {
+			displayMap[formatSecretDisplayName(secret.Path+pathKeySeparator+secret.Key)] = secret.Value
+		}
+	}
+
+	return displayMap
+}
@@ -0,0 +1,112 @@
+/*
+Copyright (c) 2023 Infisical Inc.
+*/
+package cmd
+
+import (
+	"testing"
+
+	"github.com/Infisical/infisical-merge/packages/models"
+)
+
+func TestFilterSecrets(t *testing.T) {
+	secrets := []models.SingleEnvironmentVariable{
+		{Key: "DB_PASSWORD", Value: "hunter2", Tags: []models.Tag{{Name: "Billing", Slug: "billing"}}},
+		{Key: "API_KEY", Value: "abc123", Tags: []models.Tag{{Name: "Infra", Slug: "infra"}}},
+		{Key: "DB_HOST", Value: "localhost"},
+	}
+
+	t.Run("no tags or filter returns every secret", func(t *testing.T) {
+		got, err := filterSecrets(secrets, "", "")
+		if err != nil {
+			t.Fatalf("filterSecrets() unexpected error: %v", err)
+		}
+		if len(got) != len(secrets) {
+			t.Errorf("filterSecrets() = %d secrets, want %d", len(got), len(secrets))
+		}
+	})
+
+	t.Run("tags narrows by slug or name", func(t *testing.T) {
+		got, err := filterSecrets(secrets, "billing", "")
+		if err != nil {
+			t.Fatalf("filterSecrets() unexpected error: %v", err)
+		}
+		if len(got) != 1 || got[0].Key != "DB_PASSWORD" {
+			t.Errorf("filterSecrets() = %v, want only DB_PASSWORD", got)
+		}
+	})
+
+	t.Run("filter by key regex", func(t *testing.T) {
+		got, err := filterSecrets(secrets, "", "key=^DB_.*")
+		if err != nil {
+			t.Fatalf("filterSecrets() unexpected error: %v", err)
+		}
+		if len(got) != 2 {
+			t.Errorf("filterSecrets() = %d secrets, want 2", len(got))
+		}
+	})
+
+	t.Run("filter by value regex", func(t *testing.T) {
+		got, err := filterSecrets(secrets, "", "value=^abc.*")
+		if err != nil {
+			t.Fatalf("filterSecrets() unexpected error: %v", err)
+		}
+		if len(got) != 1 || got[0].Key != "API_KEY" {
+			t.Errorf("filterSecrets() = %v, want only API_KEY", got)
+		}
+	})
+
+	t.Run("tags and filter combine", func(t *testing.T) {
+		got, err := filterSecrets(secrets, "billing", "key=^DB_.*")
+		if err != nil {
+			t.Fatalf("filterSecrets() unexpected error: %v", err)
+		}
+		if len(got) != 1 || got[0].Key != "DB_PASSWORD" {
+			t.Errorf("filterSecrets() = %v, want only DB_PASSWORD", got)
+		}
+	})
+
+	t.Run("malformed filter expression errors", func(t *testing.T) {
+		if _, err := filterSecrets(secrets, "", "no-equals-sign"); err == nil {
+			t.Fatal("filterSecrets() expected an error for a malformed --filter")
+		}
+	})
+
+	t.Run("unsupported filter field errors", func(t *testing.T) {
+		if _, err := filterSecrets(secrets, "", "comment=.*"); err == nil {
+			t.Fatal("filterSecrets() expected an error for an unsupported --filter field")
+		}
+	})
+
+	t.Run("invalid regex errors", func(t *testing.T) {
+		if _, err := filterSecrets(secrets, "", "key=("); err == nil {
+			t.Fatal("filterSecrets() expected an error for an invalid regex")
+		}
+	})
+}
+
+func TestSecretsToDisplayMap(t *testing.T) {
+	t.Run("unique keys render bare", func(t *testing.T) {
+		secrets := []models.SingleEnvironmentVariable{
+			{Key: "DB_HOST", Value: "localhost", Path: "/"},
+		}
+		got := secretsToDisplayMap(secrets)
+		if len(got) != 1 || got["DB_HOST"] != "localhost" {
+			t.Errorf("secretsToDisplayMap() = %v, want {DB_HOST: localhost}", got)
+		}
+	})
+
+	t.Run("same key in different folders is qualified instead of dropped", func(t *testing.T) {
+		secrets := []models.SingleEnvironmentVariable{
+			{Key: "DB_PASSWORD", Value: "dev-value", Path: "/dev"},
+			{Key: "DB_PASSWORD", Value: "prod-value", Path: "/prod"},
+		}
+		got := secretsToDisplayMap(secrets)
+		if len(got) != 2 {
+			t.Fatalf("secretsToDisplayMap() = %v, want 2 distinct entries, one per folder", got)
+		}
+		if got["DB_PASSWORD (/dev)"] != "dev-value" || got["DB_PASSWORD (/prod)"] != "prod-value" {
+			t.Errorf("secretsToDisplayMap() = %v, want both folders' values preserved", got)
+		}
+	})
+}
@@ -0,0 +1,269 @@
+/*
+Copyright (c) 2023 Infisical Inc.
+*/
+package cmd
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/Infisical/infisical-merge/packages/models"
+	"github.com/Infisical/infisical-merge/packages/util"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v2"
+)
+
+var secretsImportCmd = &cobra.Command{
+	Example:               `secrets import .env`,
+	Short:                 "Used to import secrets from a .env, JSON, YAML or key=value file",
+	Use:                   "import <file>",
+	DisableFlagsInUseLine: true,
+	PreRun:                toggleDebug,
+	Args:                  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		environmentName, err := cmd.Flags().GetString("env")
+		if err != nil {
+			util.HandleError(err, "Unable to parse flag")
+		}
+
+		if !util.IsSecretEnvironmentValid(environmentName) {
+			util.PrintMessageAndExit("You have entered a invalid environment name", "Environment names can only be prod, dev, test or staging")
+		}
+
+		secretsPath, err := cmd.Flags().GetString("path")
+		if err != nil {
+			util.HandleError(err, "Unable to parse path flag")
+		}
+
+		keyValuePairs, err := parseSecretsFile(args[0])
+		if err != nil {
+			util.HandleError(err, "Unable to parse the file you are attempting to import")
+		}
+
+		if len(keyValuePairs) == 0 {
+			util.PrintMessageAndExit("no key=value pairs were found in the file you are attempting to import")
+		}
+
+		setArgs := make([]string, 0, len(keyValuePairs))
+		for key, value := range keyValuePairs {
+			setArgs = append(setArgs, fmt.Sprintf("%s=%s", key, value))
+		}
+
+		setSecrets(environmentName, secretsPath, false, setArgs)
+	},
+}
+
+var secretsExportCmd = &cobra.Command{
+	Example:               `secrets export --format dotenv > .env`,
+	Short:                 "Used to export secrets in a .env, JSON, YAML, CSV or HCL format",
+	Use:                   "export",
+	DisableFlagsInUseLine: true,
+	PreRun:                toggleDebug,
+	Args:                  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		environmentName, err := cmd.Flags().GetString("env")
+		if err != nil {
+			util.HandleError(err, "Unable to parse flag")
+		}
+
+		secretsPath, err := cmd.Flags().GetString("path")
+		if err != nil {
+			util.HandleError(err, "Unable to parse path flag")
+		}
+
+		format, err := cmd.Flags().GetString("format")
+		if err != nil {
+			util.HandleError(err, "Unable to parse format flag")
+		}
+
+		tags, err := cmd.Flags().GetString("tags")
+		if err != nil {
+			util.HandleError(err, "Unable to parse tags flag")
+		}
+
+		filter, err := cmd.Flags().GetString("filter")
+		if err != nil {
+			util.HandleError(err, "Unable to parse filter flag")
+		}
+
+		shouldRecurse, err := cmd.Flags().GetBool("recursive")
+		if err != nil {
+			util.HandleError(err, "Unable to parse recursive flag")
+		}
+
+		secrets, err := util.GetAllEnvironmentVariables(models.GetAllSecretsParameters{Environment: environmentName, SecretsPath: secretsPath, Recursive: shouldRecurse})
+		if err != nil {
+			util.HandleError(err, "Unable to fetch secrets")
+		}
+
+		secrets, err = filterSecrets(secrets, tags, filter)
+		if err != nil {
+			util.HandleError(err, "Unable to apply tags/filter flags")
+		}
+
+		sort.Slice(secrets, func(i, j int) bool {
+			return secrets[i].Key < secrets[j].Key
+		})
+
+		output, err := formatSecretsForExport(secrets, format)
+		if err != nil {
+			util.HandleError(err, "Unable to export secrets in the requested format")
+		}
+
+		fmt.Print(output)
+	},
+}
+
+// parseSecretsFile reads a .env, JSON, YAML or generic key=value file and
+// returns its contents as a map of secret key to secret value. The format is
+// inferred from the file extension, falling back to a plain key=value per
+// line parser for anything else (e.g. Docker/Kubernetes style env files).
+func parseSecretsFile(path string) (map[string]string, error) {
+	fileContents, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		keyValuePairs := make(map[string]string)
+		if err := json.Unmarshal(fileContents, &keyValuePairs); err != nil {
+			return nil, err
+		}
+		return keyValuePairs, nil
+	case ".yml", ".yaml":
+		keyValuePairs := make(map[string]string)
+		if err := yaml.Unmarshal(fileContents, &keyValuePairs); err != nil {
+			return nil, err
+		}
+		return keyValuePairs, nil
+	default:
+		return parseDotEnv(string(fileContents)), nil
+	}
+}
+
+// parseDotEnv parses .env/Docker/Kubernetes style KEY=VALUE files, ignoring
+// blank lines, comments and an optional leading "export " keyword.
+func parseDotEnv(contents string) map[string]string {
+	keyValuePairs := make(map[string]string)
+
+	for _, line := range strings.Split(contents, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		line = strings.TrimPrefix(line, "export ")
+
+		splitKeyValue := strings.SplitN(line, "=", 2)
+		if len(splitKeyValue) != 2 {
+			continue
+		}
+
+		key := strings.TrimSpace(splitKeyValue[0])
+		value := strings.TrimSpace(splitKeyValue[1])
+		value = strings.Trim(value, `"'`)
+
+		if key == "" {
+			continue
+		}
+
+		keyValuePairs[key] = value
+	}
+
+	return keyValuePairs
+}
+
+// formatSecretsForExport renders decrypted secrets in one of the supported
+// export formats: dotenv, json, yaml, dotenv-export or csv.
+func formatSecretsForExport(secrets []models.SingleEnvironmentVariable, format string) (string, error) {
+	switch format {
+	case "", "dotenv":
+		if err := rejectMultilineValues(secrets, format); err != nil {
+			return "", err
+		}
+		var builder strings.Builder
+		for _, secret := range secrets {
+			builder.WriteString(fmt.Sprintf("%s=%s\n", secret.Key, secret.Value))
+		}
+		return builder.String(), nil
+
+	case "dotenv-export":
+		if err := rejectMultilineValues(secrets, format); err != nil {
+			return "", err
+		}
+		var builder strings.Builder
+		for _, secret := range secrets {
+			builder.WriteString(fmt.Sprintf("export %s=%s\n", secret.Key, secret.Value))
+		}
+		return builder.String(), nil
+
+	case "json":
+		marshalled, err := json.MarshalIndent(secretsToDisplayMap(secrets), "", "  ")
+		if err != nil {
+			return "", err
+		}
+		return string(marshalled) + "\n", nil
+
+	case "yaml":
+		marshalled, err := yaml.Marshal(secretsToDisplayMap(secrets))
+		if err != nil {
+			return "", err
+		}
+		return string(marshalled), nil
+
+	case "csv":
+		var builder strings.Builder
+		writer := csv.NewWriter(&builder)
+		if err := writer.Write([]string{"KEY", "VALUE"}); err != nil {
+			return "", err
+		}
+		for _, secret := range secrets {
+			if err := writer.Write([]string{secret.Key, secret.Value}); err != nil {
+				return "", err
+			}
+		}
+		writer.Flush()
+		return builder.String(), writer.Error()
+
+	case "hcl":
+		var builder strings.Builder
+		for _, secret := range secrets {
+			builder.WriteString(fmt.Sprintf("%s = %q\n", secret.Key, secret.Value))
+		}
+		return builder.String(), nil
+
+	default:
+		return "", fmt.Errorf("unsupported export format %q, expected one of: dotenv, json, yaml, dotenv-export, csv, hcl", format)
+	}
+}
+
+// rejectMultilineValues errors out if any secret's value contains a newline,
+// since dotenv/dotenv-export write unquoted KEY=VALUE lines and parseDotEnv
+// has no way to tell a continuation line from a new KEY=VALUE pair on
+// re-import - multi-line values (e.g. PEM keys from [infisical secrets
+// rotate --generator rsa]) would silently lose everything past the first line.
+func rejectMultilineValues(secrets []models.SingleEnvironmentVariable, format string) error {
+	for _, secret := range secrets {
+		if strings.Contains(secret.Value, "\n") {
+			return fmt.Errorf("secret %q contains a multi-line value, which --format %s cannot round-trip safely; use --format json, yaml or csv instead", secret.Key, format)
+		}
+	}
+	return nil
+}
+
+func init() {
+	secretsCmd.AddCommand(secretsImportCmd)
+	secretsImportCmd.PersistentPreRun = func(cmd *cobra.Command, args []string) {
+		util.RequireLogin()
+		util.RequireLocalWorkspaceFile()
+	}
+
+	secretsCmd.AddCommand(secretsExportCmd)
+	secretsExportCmd.Flags().String("format", "dotenv", "The format to export secrets in: dotenv, json, yaml, dotenv-export, csv or hcl")
+}
@@ -0,0 +1,154 @@
+/*
+Copyright (c) 2023 Infisical Inc.
+*/
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Infisical/infisical-merge/packages/models"
+)
+
+func TestParseDotEnv(t *testing.T) {
+	tests := []struct {
+		name     string
+		contents string
+		want     map[string]string
+	}{
+		{
+			name:     "simple key value",
+			contents: "DB_HOST=localhost\nDB_PORT=5432",
+			want:     map[string]string{"DB_HOST": "localhost", "DB_PORT": "5432"},
+		},
+		{
+			name:     "blank lines and comments are ignored",
+			contents: "# a comment\n\nAPI_KEY=abc123\n\n# trailing comment",
+			want:     map[string]string{"API_KEY": "abc123"},
+		},
+		{
+			name:     "leading export keyword is stripped",
+			contents: "export FOO=bar",
+			want:     map[string]string{"FOO": "bar"},
+		},
+		{
+			name:     "surrounding quotes are trimmed from the value",
+			contents: "FOO=\"bar\"\nBAZ='qux'",
+			want:     map[string]string{"FOO": "bar", "BAZ": "qux"},
+		},
+		{
+			name:     "lines without an equals sign are ignored",
+			contents: "NOT_A_PAIR\nFOO=bar",
+			want:     map[string]string{"FOO": "bar"},
+		},
+		{
+			name:     "values may contain additional equals signs",
+			contents: "CONNECTION_STRING=host=localhost;port=5432",
+			want:     map[string]string{"CONNECTION_STRING": "host=localhost;port=5432"},
+		},
+		{
+			name:     "a missing key is ignored",
+			contents: "=noKey",
+			want:     map[string]string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := parseDotEnv(tt.contents)
+			if len(got) != len(tt.want) {
+				t.Fatalf("parseDotEnv() = %v, want %v", got, tt.want)
+			}
+			for key, value := range tt.want {
+				if got[key] != value {
+					t.Errorf("parseDotEnv()[%q] = %q, want %q", key, got[key], value)
+				}
+			}
+		})
+	}
+}
+
+func TestFormatSecretsForExport(t *testing.T) {
+	secrets := []models.SingleEnvironmentVariable{
+		{Key: "FOO", Value: "bar"},
+		{Key: "BAZ", Value: "qux"},
+	}
+
+	tests := []struct {
+		name    string
+		format  string
+		want    string
+		wantErr bool
+	}{
+		{name: "default format is dotenv", format: "", want: "FOO=bar\nBAZ=qux\n"},
+		{name: "dotenv", format: "dotenv", want: "FOO=bar\nBAZ=qux\n"},
+		{name: "dotenv-export", format: "dotenv-export", want: "export FOO=bar\nexport BAZ=qux\n"},
+		{name: "unsupported format", format: "xml", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := formatSecretsForExport(secrets, tt.format)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("formatSecretsForExport() expected an error, got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("formatSecretsForExport() unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("formatSecretsForExport() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	t.Run("json contains every key", func(t *testing.T) {
+		got, err := formatSecretsForExport(secrets, "json")
+		if err != nil {
+			t.Fatalf("formatSecretsForExport() unexpected error: %v", err)
+		}
+		if !strings.Contains(got, `"FOO": "bar"`) || !strings.Contains(got, `"BAZ": "qux"`) {
+			t.Errorf("formatSecretsForExport() json output missing expected keys: %s", got)
+		}
+	})
+
+	t.Run("multi-line values are rejected for dotenv formats", func(t *testing.T) {
+		multiline := []models.SingleEnvironmentVariable{{Key: "PRIVATE_KEY", Value: "-----BEGIN KEY-----\nabc\n-----END KEY-----"}}
+
+		if _, err := formatSecretsForExport(multiline, "dotenv"); err == nil {
+			t.Error("formatSecretsForExport(..., \"dotenv\") expected an error for a multi-line value")
+		}
+		if _, err := formatSecretsForExport(multiline, "dotenv-export"); err == nil {
+			t.Error("formatSecretsForExport(..., \"dotenv-export\") expected an error for a multi-line value")
+		}
+		if _, err := formatSecretsForExport(multiline, "json"); err != nil {
+			t.Errorf("formatSecretsForExport(..., \"json\") unexpected error for a multi-line value: %v", err)
+		}
+	})
+
+	t.Run("json keeps same-key secrets from different folders distinct", func(t *testing.T) {
+		collidingSecrets := []models.SingleEnvironmentVariable{
+			{Key: "DB_PASSWORD", Value: "dev-value", Path: "/dev"},
+			{Key: "DB_PASSWORD", Value: "prod-value", Path: "/prod"},
+		}
+		got, err := formatSecretsForExport(collidingSecrets, "json")
+		if err != nil {
+			t.Fatalf("formatSecretsForExport() unexpected error: %v", err)
+		}
+		if !strings.Contains(got, `"DB_PASSWORD (/dev)": "dev-value"`) || !strings.Contains(got, `"DB_PASSWORD (/prod)": "prod-value"`) {
+			t.Errorf("formatSecretsForExport() json output dropped a colliding key: %s", got)
+		}
+	})
+
+	t.Run("csv includes a header row", func(t *testing.T) {
+		got, err := formatSecretsForExport(secrets, "csv")
+		if err != nil {
+			t.Fatalf("formatSecretsForExport() unexpected error: %v", err)
+		}
+		if !strings.HasPrefix(got, "KEY,VALUE\n") {
+			t.Errorf("formatSecretsForExport() csv output missing header: %s", got)
+		}
+	})
+}
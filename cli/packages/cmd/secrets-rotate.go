@@ -0,0 +1,352 @@
+/*
+Copyright (c) 2023 Infisical Inc.
+*/
+package cmd
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"os"
+	"os/exec"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Infisical/infisical-merge/packages/api"
+	"github.com/Infisical/infisical-merge/packages/crypto"
+	"github.com/Infisical/infisical-merge/packages/models"
+	"github.com/Infisical/infisical-merge/packages/util"
+	"github.com/Infisical/infisical-merge/packages/visualize"
+	"github.com/go-resty/resty/v2"
+	"github.com/spf13/cobra"
+)
+
+const defaultPasswordCharset = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789!@#$%^&*()_+-="
+
+var secretsRotateCmd = &cobra.Command{
+	Example:               `secrets rotate DB_PASSWORD API_KEY --generator password`,
+	Short:                 "Used to regenerate and replace the value of existing secrets",
+	Use:                   "rotate [secrets]",
+	DisableFlagsInUseLine: true,
+	PreRun:                toggleDebug,
+	Args:                  cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		environmentName, err := cmd.Flags().GetString("env")
+		if err != nil {
+			util.HandleError(err, "Unable to parse flag")
+		}
+
+		secretsPath, err := cmd.Flags().GetString("path")
+		if err != nil {
+			util.HandleError(err, "Unable to parse path flag")
+		}
+
+		shouldRecurse, err := cmd.Flags().GetBool("recursive")
+		if err != nil {
+			util.HandleError(err, "Unable to parse recursive flag")
+		}
+
+		generator, err := cmd.Flags().GetString("generator")
+		if err != nil {
+			util.HandleError(err, "Unable to parse generator flag")
+		}
+
+		length, err := cmd.Flags().GetInt("length")
+		if err != nil {
+			util.HandleError(err, "Unable to parse length flag")
+		}
+
+		charset, err := cmd.Flags().GetString("charset")
+		if err != nil {
+			util.HandleError(err, "Unable to parse charset flag")
+		}
+
+		shouldBackup, err := cmd.Flags().GetBool("backup")
+		if err != nil {
+			util.HandleError(err, "Unable to parse backup flag")
+		}
+
+		workspaceFile, err := util.GetWorkSpaceFromFile()
+		if err != nil {
+			util.HandleError(err, "Unable to get your local config details")
+		}
+
+		loggedInUserDetails, err := util.GetCurrentLoggedInUserDetails()
+		if err != nil {
+			util.HandleError(err, "Unable to authenticate")
+		}
+
+		httpClient := resty.New().
+			SetAuthToken(loggedInUserDetails.UserCredentials.JTWToken).
+			SetHeader("Accept", "application/json")
+
+		workspaceKeyRequest := api.GetEncryptedWorkspaceKeyRequest{
+			WorkspaceId: workspaceFile.WorkspaceId,
+		}
+
+		workspaceKeyResponse, err := api.CallGetEncryptedWorkspaceKey(httpClient, workspaceKeyRequest)
+		if err != nil {
+			util.HandleError(err, "unable to get your encrypted workspace key")
+		}
+
+		encryptedWorkspaceKey, _ := base64.StdEncoding.DecodeString(workspaceKeyResponse.EncryptedKey)
+		encryptedWorkspaceKeySenderPublicKey, _ := base64.StdEncoding.DecodeString(workspaceKeyResponse.Sender.PublicKey)
+		encryptedWorkspaceKeyNonce, _ := base64.StdEncoding.DecodeString(workspaceKeyResponse.Nonce)
+		currentUsersPrivateKey, _ := base64.StdEncoding.DecodeString(loggedInUserDetails.UserCredentials.PrivateKey)
+
+		// decrypt workspace key
+		plainTextEncryptionKey := crypto.DecryptAsymmetric(encryptedWorkspaceKey, encryptedWorkspaceKeyNonce, encryptedWorkspaceKeySenderPublicKey, currentUsersPrivateKey)
+
+		secrets, err := util.GetAllEnvironmentVariables(models.GetAllSecretsParameters{Environment: environmentName, SecretsPath: secretsPath, Recursive: shouldRecurse})
+		if err != nil {
+			util.HandleError(err, "unable to retrieve secrets")
+		}
+
+		secretByKey := getSecretsByKeys(secrets)
+
+		unknownKeys := []string{}
+		ambiguousKeys := []string{}
+		resolvedSecretByKey := make(map[string]models.SingleEnvironmentVariable)
+
+		for _, key := range args {
+			key = strings.ToUpper(key)
+			existingSecret, err := resolveUniqueSecretByKey(secretByKey, key)
+			if err != nil {
+				if len(secretByKey[key]) > 1 {
+					ambiguousKeys = append(ambiguousKeys, key)
+				} else {
+					unknownKeys = append(unknownKeys, key)
+				}
+				continue
+			}
+			resolvedSecretByKey[key] = existingSecret
+		}
+
+		if len(unknownKeys) != 0 {
+			message := fmt.Sprintf("secret name(s) [%v] does not exist in your project, rotate only replaces existing secrets. To see which secrets exist run [infisical secrets]", strings.Join(unknownKeys, ", "))
+			util.PrintMessageAndExit(message)
+		}
+
+		if len(ambiguousKeys) != 0 {
+			message := fmt.Sprintf("secret name(s) [%v] exist in more than one folder under --recursive, narrow down with --path to target a single one", strings.Join(ambiguousKeys, ", "))
+			util.PrintMessageAndExit(message)
+		}
+
+		secretsToModifyByPath := make(map[string][]api.Secret)
+		secretOperations := []SecretSetOperation{}
+		backupLines := []string{}
+
+		for _, key := range args {
+			key = strings.ToUpper(key)
+			existingSecret := resolvedSecretByKey[key]
+
+			newValue, err := generateSecretValue(generator, length, charset)
+			if err != nil {
+				util.HandleError(err, "Unable to generate a new value for "+key)
+			}
+
+			if shouldBackup {
+				backupLines = append(backupLines, fmt.Sprintf("%s\t%s\t%s", time.Now().Format(time.RFC3339), key, existingSecret.Value))
+			}
+
+			hashedValue := fmt.Sprintf("%x", sha256.Sum256([]byte(newValue)))
+			encryptedValue, err := crypto.EncryptSymmetric([]byte(newValue), []byte(plainTextEncryptionKey))
+			if err != nil {
+				util.HandleError(err, "unable to encrypt your secrets")
+			}
+
+			secretsToModifyByPath[existingSecret.Path] = append(secretsToModifyByPath[existingSecret.Path], api.Secret{
+				ID:                    existingSecret.ID,
+				SecretValueCiphertext: base64.StdEncoding.EncodeToString(encryptedValue.CipherText),
+				SecretValueIV:         base64.StdEncoding.EncodeToString(encryptedValue.Nonce),
+				SecretValueTag:        base64.StdEncoding.EncodeToString(encryptedValue.AuthTag),
+				SecretValueHash:       hashedValue,
+				SecretPath:            existingSecret.Path,
+			})
+
+			secretOperations = append(secretOperations, SecretSetOperation{
+				SecretKey:       key,
+				SecretValue:     newValue,
+				SecretOperation: "SECRET ROTATED",
+			})
+		}
+
+		if shouldBackup {
+			backupFile, err := os.OpenFile(".infisical-rotate-backup.log", os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+			if err != nil {
+				util.HandleError(err, "Unable to open the rotate backup file")
+			}
+			defer backupFile.Close()
+
+			for _, line := range backupLines {
+				if _, err := backupFile.WriteString(line + "\n"); err != nil {
+					util.HandleError(err, "Unable to write to the rotate backup file")
+				}
+			}
+		}
+
+		// secrets can live in more than one folder when --recursive is used,
+		// so submit one batch per folder rather than forcing them all
+		// through secretsPath
+		modifyPaths := make([]string, 0, len(secretsToModifyByPath))
+		for path := range secretsToModifyByPath {
+			modifyPaths = append(modifyPaths, path)
+		}
+		sort.Strings(modifyPaths)
+
+		for _, path := range modifyPaths {
+			batchModifyRequest := api.BatchModifySecretsByWorkspaceAndEnvRequest{
+				WorkspaceId: workspaceFile.WorkspaceId,
+				Environment: environmentName,
+				SecretPath:  path,
+				Secrets:     secretsToModifyByPath[path],
+			}
+
+			err = api.CallBatchModifySecretsByWorkspaceAndEnv(httpClient, batchModifyRequest)
+			if err != nil {
+				util.HandleError(err, "Unable to process the rotation of your secrets")
+			}
+		}
+
+		headers := []string{"SECRET NAME", "NEW SECRET VALUE", "STATUS"}
+		rows := [][]string{}
+		for _, secretOperation := range secretOperations {
+			rows = append(rows, []string{secretOperation.SecretKey, secretOperation.SecretValue, secretOperation.SecretOperation})
+		}
+
+		visualize.Table(headers, rows)
+	},
+}
+
+// generateSecretValue produces a new secret value using the requested
+// generator: password (length + charset), uuid, hex, base64, rsa, ed25519, or
+// exec:<cmd> which returns the trimmed stdout of the given shell command.
+func generateSecretValue(generator string, length int, charset string) (string, error) {
+	switch generator {
+	case "password", "hex", "base64":
+		if length <= 0 {
+			return "", fmt.Errorf("--length must be a positive number, got %d", length)
+		}
+	}
+
+	switch {
+	case generator == "password":
+		return generateRandomString(length, charset)
+	case generator == "uuid":
+		return generateUUID()
+	case generator == "hex":
+		randomBytes := make([]byte, length)
+		if _, err := rand.Read(randomBytes); err != nil {
+			return "", err
+		}
+		return hex.EncodeToString(randomBytes), nil
+	case generator == "base64":
+		randomBytes := make([]byte, length)
+		if _, err := rand.Read(randomBytes); err != nil {
+			return "", err
+		}
+		return base64.StdEncoding.EncodeToString(randomBytes), nil
+	case generator == "rsa":
+		return generateRSAPrivateKeyPEM()
+	case generator == "ed25519":
+		return generateEd25519PrivateKeyPEM()
+	case strings.HasPrefix(generator, "exec:"):
+		return runGeneratorCommand(strings.TrimPrefix(generator, "exec:"))
+	default:
+		return "", fmt.Errorf("unsupported --generator %q, expected one of: password, uuid, hex, base64, rsa, ed25519, exec:<cmd>", generator)
+	}
+}
+
+func generateRandomString(length int, charset string) (string, error) {
+	if charset == "" {
+		charset = defaultPasswordCharset
+	}
+
+	result := make([]byte, length)
+	charsetLength := big.NewInt(int64(len(charset)))
+
+	for i := range result {
+		index, err := rand.Int(rand.Reader, charsetLength)
+		if err != nil {
+			return "", err
+		}
+		result[i] = charset[index.Int64()]
+	}
+
+	return string(result), nil
+}
+
+func generateUUID() (string, error) {
+	randomBytes := make([]byte, 16)
+	if _, err := rand.Read(randomBytes); err != nil {
+		return "", err
+	}
+
+	// set version (4) and variant (RFC 4122) bits
+	randomBytes[6] = (randomBytes[6] & 0x0f) | 0x40
+	randomBytes[8] = (randomBytes[8] & 0x3f) | 0x80
+
+	return fmt.Sprintf("%x-%x-%x-%x-%x", randomBytes[0:4], randomBytes[4:6], randomBytes[6:8], randomBytes[8:10], randomBytes[10:16]), nil
+}
+
+func generateRSAPrivateKeyPEM() (string, error) {
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		return "", err
+	}
+
+	encodedKey := x509.MarshalPKCS1PrivateKey(privateKey)
+	pemBlock := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: encodedKey})
+
+	return string(pemBlock), nil
+}
+
+func generateEd25519PrivateKeyPEM() (string, error) {
+	_, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return "", err
+	}
+
+	encodedKey, err := x509.MarshalPKCS8PrivateKey(privateKey)
+	if err != nil {
+		return "", err
+	}
+
+	pemBlock := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: encodedKey})
+
+	return string(pemBlock), nil
+}
+
+func runGeneratorCommand(command string) (string, error) {
+	if command == "" {
+		return "", fmt.Errorf("exec generator requires a command, e.g. --generator exec:'openssl rand -hex 32'")
+	}
+
+	output, err := exec.Command("sh", "-c", command).Output()
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimSpace(string(output)), nil
+}
+
+func init() {
+	secretsRotateCmd.Flags().String("generator", "password", "The generator to use: password, uuid, hex, base64, rsa, ed25519 or exec:<cmd>")
+	secretsRotateCmd.Flags().Int("length", 32, "The length in characters (password) or bytes (hex, base64) of the generated value")
+	secretsRotateCmd.Flags().String("charset", defaultPasswordCharset, "The set of characters to draw from when --generator is password")
+	secretsRotateCmd.Flags().Bool("backup", false, "Write the previous value of each rotated secret to ./.infisical-rotate-backup.log before replacing it")
+
+	secretsCmd.AddCommand(secretsRotateCmd)
+	secretsRotateCmd.PersistentPreRun = func(cmd *cobra.Command, args []string) {
+		util.RequireLogin()
+		util.RequireLocalWorkspaceFile()
+	}
+}
@@ -0,0 +1,71 @@
+/*
+Copyright (c) 2023 Infisical Inc.
+*/
+package cmd
+
+import (
+	"regexp"
+	"testing"
+)
+
+func TestGenerateRandomString(t *testing.T) {
+	t.Run("result has the requested length", func(t *testing.T) {
+		got, err := generateRandomString(16, "")
+		if err != nil {
+			t.Fatalf("generateRandomString() unexpected error: %v", err)
+		}
+		if len(got) != 16 {
+			t.Errorf("generateRandomString() returned length %d, want 16", len(got))
+		}
+	})
+
+	t.Run("result only draws from a restricted charset", func(t *testing.T) {
+		got, err := generateRandomString(32, "ab")
+		if err != nil {
+			t.Fatalf("generateRandomString() unexpected error: %v", err)
+		}
+		if !regexp.MustCompile(`^[ab]+$`).MatchString(got) {
+			t.Errorf("generateRandomString() = %q, want only a/b characters", got)
+		}
+	})
+
+	t.Run("zero length returns an empty string", func(t *testing.T) {
+		got, err := generateRandomString(0, "")
+		if err != nil {
+			t.Fatalf("generateRandomString() unexpected error: %v", err)
+		}
+		if got != "" {
+			t.Errorf("generateRandomString(0, ...) = %q, want empty string", got)
+		}
+	})
+}
+
+func TestGenerateUUID(t *testing.T) {
+	got, err := generateUUID()
+	if err != nil {
+		t.Fatalf("generateUUID() unexpected error: %v", err)
+	}
+
+	pattern := `^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`
+	if !regexp.MustCompile(pattern).MatchString(got) {
+		t.Errorf("generateUUID() = %q, want a version 4 UUID matching %s", got, pattern)
+	}
+}
+
+func TestGenerateSecretValueRejectsNonPositiveLength(t *testing.T) {
+	tests := []struct {
+		generator string
+		length    int
+	}{
+		{generator: "password", length: -1},
+		{generator: "password", length: 0},
+		{generator: "hex", length: -1},
+		{generator: "base64", length: -1},
+	}
+
+	for _, tt := range tests {
+		if _, err := generateSecretValue(tt.generator, tt.length, ""); err == nil {
+			t.Errorf("generateSecretValue(%q, %d, ...) expected an error, got none", tt.generator, tt.length)
+		}
+	}
+}
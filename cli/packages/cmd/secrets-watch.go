@@ -0,0 +1,343 @@
+/*
+Copyright (c) 2023 Infisical Inc.
+*/
+package cmd
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"os"
+	"os/exec"
+	"os/signal"
+	"sort"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/Infisical/infisical-merge/packages/models"
+	"github.com/Infisical/infisical-merge/packages/util"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+var secretsWatchCmd = &cobra.Command{
+	Example:               `secrets watch --interval 30s`,
+	Short:                 "Used to watch an environment for secret changes and print a diff when they occur",
+	Use:                   "watch",
+	DisableFlagsInUseLine: true,
+	PreRun:                toggleDebug,
+	Args:                  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		environmentName, err := cmd.Flags().GetString("env")
+		if err != nil {
+			util.HandleError(err, "Unable to parse flag")
+		}
+
+		secretsPath, err := cmd.Flags().GetString("path")
+		if err != nil {
+			util.HandleError(err, "Unable to parse path flag")
+		}
+
+		shouldRecurse, err := cmd.Flags().GetBool("recursive")
+		if err != nil {
+			util.HandleError(err, "Unable to parse recursive flag")
+		}
+
+		interval, err := cmd.Flags().GetDuration("interval")
+		if err != nil {
+			util.HandleError(err, "Unable to parse interval flag")
+		}
+
+		secrets, err := util.GetAllEnvironmentVariables(models.GetAllSecretsParameters{Environment: environmentName, SecretsPath: secretsPath, Recursive: shouldRecurse})
+		if err != nil {
+			util.HandleError(err, "Unable to fetch secrets")
+		}
+
+		previousHash := hashSecrets(secrets)
+		previousValues := secretsToKeyValueMap(secrets)
+
+		fmt.Printf("Watching [%s] for secret changes every %s. Press Ctrl+C to stop.\n", environmentName, interval)
+
+		for range time.Tick(interval) {
+			secrets, err := util.GetAllEnvironmentVariables(models.GetAllSecretsParameters{Environment: environmentName, SecretsPath: secretsPath, Recursive: shouldRecurse})
+			if err != nil {
+				log.Errorf("unable to fetch secrets: %v", err)
+				continue
+			}
+
+			currentHash := hashSecrets(secrets)
+			if currentHash == previousHash {
+				continue
+			}
+
+			currentValues := secretsToKeyValueMap(secrets)
+			printSecretDrift(previousValues, currentValues)
+
+			previousHash = currentHash
+			previousValues = currentValues
+		}
+	},
+}
+
+var secretsRunCmd = &cobra.Command{
+	Example:               `secrets run --on-change=restart -- npm run start`,
+	Short:                 "Used to run a command with your secrets injected as environment variables, reacting to remote changes",
+	Use:                   "run [flags] -- <command>",
+	DisableFlagsInUseLine: true,
+	PreRun:                toggleDebug,
+	Args:                  cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		environmentName, err := cmd.Flags().GetString("env")
+		if err != nil {
+			util.HandleError(err, "Unable to parse flag")
+		}
+
+		secretsPath, err := cmd.Flags().GetString("path")
+		if err != nil {
+			util.HandleError(err, "Unable to parse path flag")
+		}
+
+		shouldRecurse, err := cmd.Flags().GetBool("recursive")
+		if err != nil {
+			util.HandleError(err, "Unable to parse recursive flag")
+		}
+
+		interval, err := cmd.Flags().GetDuration("interval")
+		if err != nil {
+			util.HandleError(err, "Unable to parse interval flag")
+		}
+
+		onChange, err := cmd.Flags().GetString("on-change")
+		if err != nil {
+			util.HandleError(err, "Unable to parse on-change flag")
+		}
+
+		if onChange != "restart" && onChange != "signal" && onChange != "noop" {
+			util.PrintMessageAndExit(fmt.Sprintf("unsupported --on-change %q, expected one of: restart, signal, noop", onChange))
+		}
+
+		secrets, err := util.GetAllEnvironmentVariables(models.GetAllSecretsParameters{Environment: environmentName, SecretsPath: secretsPath, Recursive: shouldRecurse})
+		if err != nil {
+			util.HandleError(err, "Unable to fetch secrets")
+		}
+
+		previousHash := hashSecrets(secrets)
+		childProcess, err := startChildProcess(args, secrets)
+		if err != nil {
+			util.HandleError(err, "Unable to start the given command")
+		}
+
+		interrupt := make(chan os.Signal, 1)
+		signal.Notify(interrupt, os.Interrupt, syscall.SIGTERM)
+
+		childExited := make(chan error, 1)
+		go func() {
+			childExited <- childProcess.Wait()
+		}()
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case err := <-childExited:
+				if err != nil {
+					util.HandleError(err, "Command exited with an error")
+				}
+				return
+
+			case <-interrupt:
+				_ = childProcess.Process.Signal(syscall.SIGTERM)
+				return
+
+			case <-ticker.C:
+				secrets, err := util.GetAllEnvironmentVariables(models.GetAllSecretsParameters{Environment: environmentName, SecretsPath: secretsPath, Recursive: shouldRecurse})
+				if err != nil {
+					log.Errorf("unable to fetch secrets: %v", err)
+					continue
+				}
+
+				currentHash := hashSecrets(secrets)
+				if currentHash == previousHash {
+					continue
+				}
+				previousHash = currentHash
+
+				switch onChange {
+				case "noop":
+					fmt.Println("Detected a change in your secrets (--on-change=noop, not taking any action)")
+
+				case "signal":
+					fmt.Println("Detected a change in your secrets, sending SIGHUP to the running command")
+					if err := childProcess.Process.Signal(syscall.SIGHUP); err != nil {
+						log.Errorf("unable to signal command: %v", err)
+					}
+
+				case "restart":
+					fmt.Println("Detected a change in your secrets, restarting the command")
+					waitForChildExit(childProcess, childExited)
+
+					childProcess, err = startChildProcess(args, secrets)
+					if err != nil {
+						util.HandleError(err, "Unable to restart the given command")
+					}
+
+					go func() {
+						childExited <- childProcess.Wait()
+					}()
+				}
+			}
+		}
+	},
+}
+
+// restartGracePeriod is how long waitForChildExit gives the child to exit on
+// its own after SIGTERM before escalating to SIGKILL.
+const restartGracePeriod = 10 * time.Second
+
+// waitForChildExit asks the child to terminate and blocks until it does,
+// escalating to SIGKILL if it ignores SIGTERM for longer than
+// restartGracePeriod. Without this, a child that traps/ignores SIGTERM would
+// hang the whole supervisor on this wait forever, including Ctrl+C handling.
+func waitForChildExit(childProcess *exec.Cmd, childExited chan error) {
+	_ = childProcess.Process.Signal(syscall.SIGTERM)
+
+	select {
+	case <-childExited:
+	case <-time.After(restartGracePeriod):
+		fmt.Printf("Command did not exit within %s of SIGTERM, sending SIGKILL\n", restartGracePeriod)
+		_ = childProcess.Process.Kill()
+		<-childExited
+	}
+}
+
+// startChildProcess launches args as a subprocess with the decrypted secrets
+// injected into its environment, alongside the parent's own environment.
+func startChildProcess(args []string, secrets []models.SingleEnvironmentVariable) (*exec.Cmd, error) {
+	childProcess := exec.Command(args[0], args[1:]...)
+	childProcess.Stdin = os.Stdin
+	childProcess.Stdout = os.Stdout
+	childProcess.Stderr = os.Stderr
+
+	secretEnv, err := secretsToEnvPairs(secrets)
+	if err != nil {
+		return nil, err
+	}
+	childProcess.Env = append(os.Environ(), secretEnv...)
+
+	if err := childProcess.Start(); err != nil {
+		return nil, err
+	}
+
+	return childProcess, nil
+}
+
+// secretsToEnvPairs renders secrets as "KEY=VALUE" environment entries,
+// erroring if --recursive surfaced the same key in more than one folder - a
+// single process environment can only hold one value per name, so there's no
+// safe value to silently pick between them.
+func secretsToEnvPairs(secrets []models.SingleEnvironmentVariable) ([]string, error) {
+	secretsByKey := getSecretsByKeys(secrets)
+
+	keys := make([]string, 0, len(secretsByKey))
+	for key := range secretsByKey {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	envPairs := make([]string, 0, len(secrets))
+	for _, key := range keys {
+		existingSecret, err := resolveUniqueSecretByKey(secretsByKey, key)
+		if err != nil {
+			return nil, fmt.Errorf("%w, narrow down with --path to target a single folder", err)
+		}
+		envPairs = append(envPairs, fmt.Sprintf("%s=%s", key, existingSecret.Value))
+	}
+
+	return envPairs, nil
+}
+
+// hashSecrets returns a stable hash of a secret set's sorted Key=Value pairs,
+// used to detect drift between polls without storing plaintext snapshots.
+func hashSecrets(secrets []models.SingleEnvironmentVariable) string {
+	lines := make([]string, 0, len(secrets))
+	for _, secret := range secrets {
+		lines = append(lines, fmt.Sprintf("%s=%s", secret.Key, secret.Value))
+	}
+	sort.Strings(lines)
+
+	return fmt.Sprintf("%x", sha256.Sum256([]byte(strings.Join(lines, "\n"))))
+}
+
+// secretsToKeyValueMap maps secrets by Path+Key so that the same key living
+// in two folders under --recursive is tracked as two distinct entries
+// instead of one silently shadowing the other between polls.
+func secretsToKeyValueMap(secrets []models.SingleEnvironmentVariable) map[string]string {
+	keyValuePairs := make(map[string]string, len(secrets))
+	for _, secret := range secrets {
+		keyValuePairs[secret.Path+pathKeySeparator+secret.Key] = secret.Value
+	}
+	return keyValuePairs
+}
+
+// formatSecretDisplayName renders a secretsToKeyValueMap composite key back
+// into a human-readable name, qualifying it with its folder when that folder
+// isn't the workspace root.
+func formatSecretDisplayName(compositeKey string) string {
+	path, key, _ := strings.Cut(compositeKey, pathKeySeparator)
+	if path == "" || path == "/" {
+		return key
+	}
+	return fmt.Sprintf("%s (%s)", key, path)
+}
+
+// printSecretDrift prints which folder+key pairs were added, removed or
+// changed between two polls of the same environment.
+func printSecretDrift(previous map[string]string, current map[string]string) {
+	changedNames := []string{}
+
+	for compositeKey, currentValue := range current {
+		previousValue, existed := previous[compositeKey]
+		if !existed {
+			name := formatSecretDisplayName(compositeKey)
+			fmt.Printf("+ %s\n", name)
+			changedNames = append(changedNames, name)
+		} else if previousValue != currentValue {
+			name := formatSecretDisplayName(compositeKey)
+			fmt.Printf("~ %s\n", name)
+			changedNames = append(changedNames, name)
+		}
+	}
+
+	for compositeKey := range previous {
+		if _, stillExists := current[compositeKey]; !stillExists {
+			name := formatSecretDisplayName(compositeKey)
+			fmt.Printf("- %s\n", name)
+			changedNames = append(changedNames, name)
+		}
+	}
+
+	if len(changedNames) == 0 {
+		return
+	}
+
+	sort.Strings(changedNames)
+	fmt.Printf("[%s] %d secret(s) changed: %s\n", time.Now().Format(time.RFC3339), len(changedNames), strings.Join(changedNames, ", "))
+}
+
+func init() {
+	secretsWatchCmd.Flags().Duration("interval", 30*time.Second, "How often to poll for secret changes")
+	secretsCmd.AddCommand(secretsWatchCmd)
+	secretsWatchCmd.PersistentPreRun = func(cmd *cobra.Command, args []string) {
+		util.RequireLogin()
+		util.RequireLocalWorkspaceFile()
+	}
+
+	secretsRunCmd.Flags().Duration("interval", 30*time.Second, "How often to poll for secret changes")
+	secretsRunCmd.Flags().String("on-change", "restart", "What to do when secrets change while the command is running: restart, signal or noop")
+	secretsCmd.AddCommand(secretsRunCmd)
+	secretsRunCmd.PersistentPreRun = func(cmd *cobra.Command, args []string) {
+		util.RequireLogin()
+		util.RequireLocalWorkspaceFile()
+	}
+}
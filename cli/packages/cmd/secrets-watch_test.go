@@ -0,0 +1,85 @@
+/*
+Copyright (c) 2023 Infisical Inc.
+*/
+package cmd
+
+import (
+	"testing"
+
+	"github.com/Infisical/infisical-merge/packages/models"
+)
+
+func TestHashSecrets(t *testing.T) {
+	a := []models.SingleEnvironmentVariable{
+		{Key: "FOO", Value: "bar"},
+		{Key: "BAZ", Value: "qux"},
+	}
+	b := []models.SingleEnvironmentVariable{
+		{Key: "BAZ", Value: "qux"},
+		{Key: "FOO", Value: "bar"},
+	}
+	changed := []models.SingleEnvironmentVariable{
+		{Key: "FOO", Value: "bar"},
+		{Key: "BAZ", Value: "different"},
+	}
+
+	t.Run("key order does not affect the hash", func(t *testing.T) {
+		if hashSecrets(a) != hashSecrets(b) {
+			t.Error("hashSecrets() differed for the same secrets in a different order")
+		}
+	})
+
+	t.Run("a changed value produces a different hash", func(t *testing.T) {
+		if hashSecrets(a) == hashSecrets(changed) {
+			t.Error("hashSecrets() matched for secret sets with a different value")
+		}
+	})
+
+	t.Run("empty secret set is stable", func(t *testing.T) {
+		if hashSecrets(nil) != hashSecrets([]models.SingleEnvironmentVariable{}) {
+			t.Error("hashSecrets() differed between nil and empty secret slices")
+		}
+	})
+}
+
+func TestSecretsToKeyValueMap(t *testing.T) {
+	secrets := []models.SingleEnvironmentVariable{
+		{Key: "FOO", Value: "bar", Path: "/"},
+		{Key: "BAZ", Value: "qux", Path: "/"},
+	}
+
+	got := secretsToKeyValueMap(secrets)
+	want := map[string]string{"/\x00FOO": "bar", "/\x00BAZ": "qux"}
+	if len(got) != len(want) {
+		t.Errorf("secretsToKeyValueMap() = %v, want %v", got, want)
+	}
+	for key, value := range want {
+		if got[key] != value {
+			t.Errorf("secretsToKeyValueMap()[%q] = %q, want %q", key, got[key], value)
+		}
+	}
+}
+
+func TestSecretsToKeyValueMapKeepsSameKeyInDifferentFoldersDistinct(t *testing.T) {
+	secrets := []models.SingleEnvironmentVariable{
+		{Key: "DB_PASSWORD", Value: "dev-value", Path: "/dev"},
+		{Key: "DB_PASSWORD", Value: "prod-value", Path: "/prod"},
+	}
+
+	got := secretsToKeyValueMap(secrets)
+	if len(got) != 2 {
+		t.Fatalf("secretsToKeyValueMap() = %v, want 2 distinct entries, one per folder", got)
+	}
+	if got["/dev\x00DB_PASSWORD"] != "dev-value" || got["/prod\x00DB_PASSWORD"] != "prod-value" {
+		t.Errorf("secretsToKeyValueMap() = %v, want both folders' values preserved", got)
+	}
+}
+
+func TestFormatSecretDisplayName(t *testing.T) {
+	if got := formatSecretDisplayName("/\x00FOO"); got != "FOO" {
+		t.Errorf("formatSecretDisplayName() at workspace root = %q, want %q", got, "FOO")
+	}
+	if got := formatSecretDisplayName("/dev\x00DB_PASSWORD"); got != "DB_PASSWORD (/dev)" {
+		t.Errorf("formatSecretDisplayName() = %q, want %q", got, "DB_PASSWORD (/dev)")
+	}
+}
@@ -46,7 +46,32 @@ var secretsCmd = &cobra.Command{
 			util.HandleError(err)
 		}
 
-		secrets, err := util.GetAllEnvironmentVariables(models.GetAllSecretsParameters{Environment: environmentName, InfisicalToken: infisicalToken})
+		secretsPath, err := cmd.Flags().GetString("path")
+		if err != nil {
+			util.HandleError(err, "Unable to parse path flag")
+		}
+
+		shouldRecurse, err := cmd.Flags().GetBool("recursive")
+		if err != nil {
+			util.HandleError(err, "Unable to parse recursive flag")
+		}
+
+		tags, err := cmd.Flags().GetString("tags")
+		if err != nil {
+			util.HandleError(err, "Unable to parse tags flag")
+		}
+
+		filter, err := cmd.Flags().GetString("filter")
+		if err != nil {
+			util.HandleError(err, "Unable to parse filter flag")
+		}
+
+		output, err := cmd.Flags().GetString("output")
+		if err != nil {
+			util.HandleError(err, "Unable to parse output flag")
+		}
+
+		secrets, err := util.GetAllEnvironmentVariables(models.GetAllSecretsParameters{Environment: environmentName, InfisicalToken: infisicalToken, SecretsPath: secretsPath, Recursive: shouldRecurse})
 		if err != nil {
 			util.HandleError(err)
 		}
@@ -55,7 +80,12 @@ var secretsCmd = &cobra.Command{
 			secrets = util.SubstituteSecrets(secrets)
 		}
 
-		visualize.PrintAllSecretDetails(secrets)
+		secrets, err = filterSecrets(secrets, tags, filter)
+		if err != nil {
+			util.HandleError(err, "Unable to apply tags/filter flags")
+		}
+
+		printSecretsWithOutput(secrets, output)
 	},
 }
 
@@ -96,149 +126,202 @@ var secretsSetCmd = &cobra.Command{
 			util.PrintMessageAndExit("You have entered a invalid environment name", "Environment names can only be prod, dev, test or staging")
 		}
 
-		workspaceFile, err := util.GetWorkSpaceFromFile()
+		secretsPath, err := cmd.Flags().GetString("path")
 		if err != nil {
-			util.HandleError(err, "Unable to get your local config details")
+			util.HandleError(err, "Unable to parse path flag")
 		}
 
-		loggedInUserDetails, err := util.GetCurrentLoggedInUserDetails()
+		shouldRecurse, err := cmd.Flags().GetBool("recursive")
 		if err != nil {
-			util.HandleError(err, "Unable to authenticate")
+			util.HandleError(err, "Unable to parse recursive flag")
 		}
 
-		httpClient := resty.New().
-			SetAuthToken(loggedInUserDetails.UserCredentials.JTWToken).
-			SetHeader("Accept", "application/json")
+		setSecrets(environmentName, secretsPath, shouldRecurse, args)
+	},
+}
 
-		request := api.GetEncryptedWorkspaceKeyRequest{
-			WorkspaceId: workspaceFile.WorkspaceId,
-		}
+// SecretSetOperation describes the outcome of applying a single KEY=VALUE pair
+// to a workspace/environment, used to render the summary table after a set or
+// import operation.
+type SecretSetOperation struct {
+	SecretKey       string
+	SecretValue     string
+	SecretOperation string
+}
 
-		workspaceKeyResponse, err := api.CallGetEncryptedWorkspaceKey(httpClient, request)
-		if err != nil {
-			util.HandleError(err, "unable to get your encrypted workspace key")
-		}
+// setSecrets encrypts and upserts the given "KEY=VALUE" pairs against the
+// provided environment and secret path, then prints a summary table of the
+// operations that were performed. It backs both `secrets set` and
+// `secrets import`.
+//
+// New secrets are always created at secretsPath. When recursive is true, an
+// existing secret found anywhere under secretsPath's subtree is modified in
+// place at its own folder instead of being shadowed by a duplicate created at
+// secretsPath; a key that matches in more than one folder is rejected since
+// there's no single secret to modify.
+func setSecrets(environmentName string, secretsPath string, recursive bool, args []string) {
+	workspaceFile, err := util.GetWorkSpaceFromFile()
+	if err != nil {
+		util.HandleError(err, "Unable to get your local config details")
+	}
 
-		encryptedWorkspaceKey, _ := base64.StdEncoding.DecodeString(workspaceKeyResponse.EncryptedKey)
-		encryptedWorkspaceKeySenderPublicKey, _ := base64.StdEncoding.DecodeString(workspaceKeyResponse.Sender.PublicKey)
-		encryptedWorkspaceKeyNonce, _ := base64.StdEncoding.DecodeString(workspaceKeyResponse.Nonce)
-		currentUsersPrivateKey, _ := base64.StdEncoding.DecodeString(loggedInUserDetails.UserCredentials.PrivateKey)
+	loggedInUserDetails, err := util.GetCurrentLoggedInUserDetails()
+	if err != nil {
+		util.HandleError(err, "Unable to authenticate")
+	}
 
-		// decrypt workspace key
-		plainTextEncryptionKey := crypto.DecryptAsymmetric(encryptedWorkspaceKey, encryptedWorkspaceKeyNonce, encryptedWorkspaceKeySenderPublicKey, currentUsersPrivateKey)
+	httpClient := resty.New().
+		SetAuthToken(loggedInUserDetails.UserCredentials.JTWToken).
+		SetHeader("Accept", "application/json")
 
-		// pull current secrets
-		secrets, err := util.GetAllEnvironmentVariables(models.GetAllSecretsParameters{Environment: environmentName})
-		if err != nil {
-			util.HandleError(err, "unable to retrieve secrets")
-		}
+	request := api.GetEncryptedWorkspaceKeyRequest{
+		WorkspaceId: workspaceFile.WorkspaceId,
+	}
 
-		type SecretSetOperation struct {
-			SecretKey       string
-			SecretValue     string
-			SecretOperation string
-		}
+	workspaceKeyResponse, err := api.CallGetEncryptedWorkspaceKey(httpClient, request)
+	if err != nil {
+		util.HandleError(err, "unable to get your encrypted workspace key")
+	}
 
-		secretsToCreate := []api.Secret{}
-		secretsToModify := []api.Secret{}
-		secretOperations := []SecretSetOperation{}
+	encryptedWorkspaceKey, _ := base64.StdEncoding.DecodeString(workspaceKeyResponse.EncryptedKey)
+	encryptedWorkspaceKeySenderPublicKey, _ := base64.StdEncoding.DecodeString(workspaceKeyResponse.Sender.PublicKey)
+	encryptedWorkspaceKeyNonce, _ := base64.StdEncoding.DecodeString(workspaceKeyResponse.Nonce)
+	currentUsersPrivateKey, _ := base64.StdEncoding.DecodeString(loggedInUserDetails.UserCredentials.PrivateKey)
 
-		secretByKey := getSecretsByKeys(secrets)
+	// decrypt workspace key
+	plainTextEncryptionKey := crypto.DecryptAsymmetric(encryptedWorkspaceKey, encryptedWorkspaceKeyNonce, encryptedWorkspaceKeySenderPublicKey, currentUsersPrivateKey)
 
-		for _, arg := range args {
-			splitKeyValueFromArg := strings.SplitN(arg, "=", 2)
-			if splitKeyValueFromArg[0] == "" || splitKeyValueFromArg[1] == "" {
-				util.PrintMessageAndExit("ensure that each secret has a none empty key and value. Modify the input and try again")
-			}
+	// pull current secrets
+	secrets, err := util.GetAllEnvironmentVariables(models.GetAllSecretsParameters{Environment: environmentName, SecretsPath: secretsPath, Recursive: recursive})
+	if err != nil {
+		util.HandleError(err, "unable to retrieve secrets")
+	}
 
-			if unicode.IsNumber(rune(splitKeyValueFromArg[0][0])) {
-				util.PrintMessageAndExit("keys of secrets cannot start with a number. Modify the key name(s) and try again")
-			}
+	secretsToCreate := []api.Secret{}
+	secretsToModifyByPath := make(map[string][]api.Secret)
+	secretOperations := []SecretSetOperation{}
 
-			// Key and value from argument
-			key := strings.ToUpper(splitKeyValueFromArg[0])
-			value := splitKeyValueFromArg[1]
+	secretByKey := getSecretsByKeys(secrets)
 
-			hashedKey := fmt.Sprintf("%x", sha256.Sum256([]byte(key)))
-			encryptedKey, err := crypto.EncryptSymmetric([]byte(key), []byte(plainTextEncryptionKey))
-			if err != nil {
-				util.HandleError(err, "unable to encrypt your secrets")
-			}
+	for _, arg := range args {
+		splitKeyValueFromArg := strings.SplitN(arg, "=", 2)
+		if splitKeyValueFromArg[0] == "" || splitKeyValueFromArg[1] == "" {
+			util.PrintMessageAndExit("ensure that each secret has a none empty key and value. Modify the input and try again")
+		}
 
-			hashedValue := fmt.Sprintf("%x", sha256.Sum256([]byte(value)))
-			encryptedValue, err := crypto.EncryptSymmetric([]byte(value), []byte(plainTextEncryptionKey))
-			if err != nil {
-				util.HandleError(err, "unable to encrypt your secrets")
-			}
+		if unicode.IsNumber(rune(splitKeyValueFromArg[0][0])) {
+			util.PrintMessageAndExit("keys of secrets cannot start with a number. Modify the key name(s) and try again")
+		}
 
-			if existingSecret, ok := secretByKey[key]; ok {
-				// case: secret exists in project so it needs to be modified
-				encryptedSecretDetails := api.Secret{
-					ID:                    existingSecret.ID,
-					SecretValueCiphertext: base64.StdEncoding.EncodeToString(encryptedValue.CipherText),
-					SecretValueIV:         base64.StdEncoding.EncodeToString(encryptedValue.Nonce),
-					SecretValueTag:        base64.StdEncoding.EncodeToString(encryptedValue.AuthTag),
-					SecretValueHash:       hashedValue,
-				}
+		// Key and value from argument
+		key := strings.ToUpper(splitKeyValueFromArg[0])
+		value := splitKeyValueFromArg[1]
 
-				// Only add to modifications if the value is different
-				if existingSecret.Value != value {
-					secretsToModify = append(secretsToModify, encryptedSecretDetails)
-					secretOperations = append(secretOperations, SecretSetOperation{
-						SecretKey:       key,
-						SecretValue:     value,
-						SecretOperation: "SECRET VALUE MODIFIED",
-					})
-				} else {
-					// Current value is same as exisitng so no change
-					secretOperations = append(secretOperations, SecretSetOperation{
-						SecretKey:       key,
-						SecretValue:     value,
-						SecretOperation: "SECRET VALUE UNCHANGED",
-					})
-				}
+		hashedKey := fmt.Sprintf("%x", sha256.Sum256([]byte(key)))
+		encryptedKey, err := crypto.EncryptSymmetric([]byte(key), []byte(plainTextEncryptionKey))
+		if err != nil {
+			util.HandleError(err, "unable to encrypt your secrets")
+		}
+
+		hashedValue := fmt.Sprintf("%x", sha256.Sum256([]byte(value)))
+		encryptedValue, err := crypto.EncryptSymmetric([]byte(value), []byte(plainTextEncryptionKey))
+		if err != nil {
+			util.HandleError(err, "unable to encrypt your secrets")
+		}
+
+		matches := secretByKey[key]
+		if len(matches) > 1 {
+			paths := make([]string, 0, len(matches))
+			for _, match := range matches {
+				paths = append(paths, match.Path)
+			}
+			util.PrintMessageAndExit(fmt.Sprintf("secret %q exists in more than one folder [%s], narrow down with --path to target a single one", key, strings.Join(paths, ", ")))
+		}
+
+		if len(matches) == 1 {
+			existingSecret := matches[0]
+			// case: secret exists in project so it needs to be modified, at
+			// its own folder rather than wherever --path/--recursive landed
+			encryptedSecretDetails := api.Secret{
+				ID:                    existingSecret.ID,
+				SecretValueCiphertext: base64.StdEncoding.EncodeToString(encryptedValue.CipherText),
+				SecretValueIV:         base64.StdEncoding.EncodeToString(encryptedValue.Nonce),
+				SecretValueTag:        base64.StdEncoding.EncodeToString(encryptedValue.AuthTag),
+				SecretValueHash:       hashedValue,
+				SecretPath:            existingSecret.Path,
+			}
 
+			// Only add to modifications if the value is different
+			if existingSecret.Value != value {
+				secretsToModifyByPath[existingSecret.Path] = append(secretsToModifyByPath[existingSecret.Path], encryptedSecretDetails)
+				secretOperations = append(secretOperations, SecretSetOperation{
+					SecretKey:       key,
+					SecretValue:     value,
+					SecretOperation: "SECRET VALUE MODIFIED",
+				})
 			} else {
-				// case: secret doesn't exist in project so it needs to be created
-				encryptedSecretDetails := api.Secret{
-					SecretKeyCiphertext:   base64.StdEncoding.EncodeToString(encryptedKey.CipherText),
-					SecretKeyIV:           base64.StdEncoding.EncodeToString(encryptedKey.Nonce),
-					SecretKeyTag:          base64.StdEncoding.EncodeToString(encryptedKey.AuthTag),
-					SecretKeyHash:         hashedKey,
-					SecretValueCiphertext: base64.StdEncoding.EncodeToString(encryptedValue.CipherText),
-					SecretValueIV:         base64.StdEncoding.EncodeToString(encryptedValue.Nonce),
-					SecretValueTag:        base64.StdEncoding.EncodeToString(encryptedValue.AuthTag),
-					SecretValueHash:       hashedValue,
-					Type:                  util.SECRET_TYPE_SHARED,
-				}
-				secretsToCreate = append(secretsToCreate, encryptedSecretDetails)
+				// Current value is same as exisitng so no change
 				secretOperations = append(secretOperations, SecretSetOperation{
 					SecretKey:       key,
 					SecretValue:     value,
-					SecretOperation: "SECRET CREATED",
+					SecretOperation: "SECRET VALUE UNCHANGED",
 				})
 			}
-		}
 
-		if len(secretsToCreate) > 0 {
-			batchCreateRequest := api.BatchCreateSecretsByWorkspaceAndEnvRequest{
-				WorkspaceId: workspaceFile.WorkspaceId,
-				Environment: environmentName,
-				Secrets:     secretsToCreate,
+		} else {
+			// case: secret doesn't exist in project so it needs to be created
+			encryptedSecretDetails := api.Secret{
+				SecretKeyCiphertext:   base64.StdEncoding.EncodeToString(encryptedKey.CipherText),
+				SecretKeyIV:           base64.StdEncoding.EncodeToString(encryptedKey.Nonce),
+				SecretKeyTag:          base64.StdEncoding.EncodeToString(encryptedKey.AuthTag),
+				SecretKeyHash:         hashedKey,
+				SecretValueCiphertext: base64.StdEncoding.EncodeToString(encryptedValue.CipherText),
+				SecretValueIV:         base64.StdEncoding.EncodeToString(encryptedValue.Nonce),
+				SecretValueTag:        base64.StdEncoding.EncodeToString(encryptedValue.AuthTag),
+				SecretValueHash:       hashedValue,
+				SecretPath:            secretsPath,
+				Type:                  util.SECRET_TYPE_SHARED,
 			}
+			secretsToCreate = append(secretsToCreate, encryptedSecretDetails)
+			secretOperations = append(secretOperations, SecretSetOperation{
+				SecretKey:       key,
+				SecretValue:     value,
+				SecretOperation: "SECRET CREATED",
+			})
+		}
+	}
 
-			err = api.CallBatchCreateSecretsByWorkspaceAndEnv(httpClient, batchCreateRequest)
-			if err != nil {
-				util.HandleError(err, "Unable to process new secret creations")
-				return
-			}
+	if len(secretsToCreate) > 0 {
+		batchCreateRequest := api.BatchCreateSecretsByWorkspaceAndEnvRequest{
+			WorkspaceId: workspaceFile.WorkspaceId,
+			Environment: environmentName,
+			SecretPath:  secretsPath,
+			Secrets:     secretsToCreate,
 		}
 
-		if len(secretsToModify) > 0 {
+		err = api.CallBatchCreateSecretsByWorkspaceAndEnv(httpClient, batchCreateRequest)
+		if err != nil {
+			util.HandleError(err, "Unable to process new secret creations")
+			return
+		}
+	}
+
+	if len(secretsToModifyByPath) > 0 {
+		// secrets can be modified in more than one folder when --recursive
+		// matched an existing key outside secretsPath, so submit one batch
+		// per folder rather than forcing them all through secretsPath
+		modifyPaths := make([]string, 0, len(secretsToModifyByPath))
+		for path := range secretsToModifyByPath {
+			modifyPaths = append(modifyPaths, path)
+		}
+		sort.Strings(modifyPaths)
+
+		for _, path := range modifyPaths {
 			batchModifyRequest := api.BatchModifySecretsByWorkspaceAndEnvRequest{
 				WorkspaceId: workspaceFile.WorkspaceId,
 				Environment: environmentName,
-				Secrets:     secretsToModify,
+				SecretPath:  path,
+				Secrets:     secretsToModifyByPath[path],
 			}
 
 			err = api.CallBatchModifySecretsByWorkspaceAndEnv(httpClient, batchModifyRequest)
@@ -247,16 +330,16 @@ var secretsSetCmd = &cobra.Command{
 				return
 			}
 		}
+	}
 
-		// Print secret operations
-		headers := []string{"SECRET NAME", "SECRET VALUE", "STATUS"}
-		rows := [][]string{}
-		for _, secretOperation := range secretOperations {
-			rows = append(rows, []string{secretOperation.SecretKey, secretOperation.SecretValue, secretOperation.SecretOperation})
-		}
+	// Print secret operations
+	headers := []string{"SECRET NAME", "SECRET VALUE", "STATUS"}
+	rows := [][]string{}
+	for _, secretOperation := range secretOperations {
+		rows = append(rows, []string{secretOperation.SecretKey, secretOperation.SecretValue, secretOperation.SecretOperation})
+	}
 
-		visualize.Table(headers, rows)
-	},
+	visualize.Table(headers, rows)
 }
 
 var secretsDeleteCmd = &cobra.Command{
@@ -265,7 +348,7 @@ var secretsDeleteCmd = &cobra.Command{
 	Use:                   "delete [secrets]",
 	DisableFlagsInUseLine: true,
 	PreRun:                toggleDebug,
-	Args:                  cobra.MinimumNArgs(1),
+	Args:                  cobra.ArbitraryArgs,
 	Run: func(cmd *cobra.Command, args []string) {
 		environmentName, err := cmd.Flags().GetString("env")
 		if err != nil {
@@ -274,6 +357,26 @@ var secretsDeleteCmd = &cobra.Command{
 			return
 		}
 
+		secretsPath, err := cmd.Flags().GetString("path")
+		if err != nil {
+			util.HandleError(err, "Unable to parse path flag")
+		}
+
+		tags, err := cmd.Flags().GetString("tags")
+		if err != nil {
+			util.HandleError(err, "Unable to parse tags flag")
+		}
+
+		filter, err := cmd.Flags().GetString("filter")
+		if err != nil {
+			util.HandleError(err, "Unable to parse filter flag")
+		}
+
+		shouldRecurse, err := cmd.Flags().GetBool("recursive")
+		if err != nil {
+			util.HandleError(err, "Unable to parse recursive flag")
+		}
+
 		loggedInUserDetails, err := util.GetCurrentLoggedInUserDetails()
 		if err != nil {
 			util.HandleError(err, "Unable to authenticate")
@@ -284,21 +387,48 @@ var secretsDeleteCmd = &cobra.Command{
 			util.HandleError(err, "Unable to get local project details")
 		}
 
-		secrets, err := util.GetAllEnvironmentVariables(models.GetAllSecretsParameters{Environment: environmentName})
+		secrets, err := util.GetAllEnvironmentVariables(models.GetAllSecretsParameters{Environment: environmentName, SecretsPath: secretsPath, Recursive: shouldRecurse})
 		if err != nil {
 			util.HandleError(err, "Unable to fetch secrets")
 		}
 
+		// secretsToDelete collects the concrete secrets (ID + Path) to
+		// delete. Matches from --tags/--filter are taken directly since
+		// they're already a specific secret, not a bare key name that could
+		// be ambiguous under --recursive.
+		secretsToDelete := []models.SingleEnvironmentVariable{}
+		explicitKeysGiven := len(args) > 0
+
+		if tags != "" || filter != "" {
+			matchedSecrets, err := filterSecrets(secrets, tags, filter)
+			if err != nil {
+				util.HandleError(err, "Unable to apply tags/filter flags")
+			}
+
+			secretsToDelete = append(secretsToDelete, matchedSecrets...)
+		}
+
+		if !explicitKeysGiven && len(secretsToDelete) == 0 {
+			util.PrintMessageAndExit("specify secret name(s) to delete, or narrow down the set to delete with --tags/--filter")
+		}
+
 		secretByKey := getSecretsByKeys(secrets)
-		validSecretIdsToDelete := []string{}
 		invalidSecretNamesThatDoNotExist := []string{}
+		ambiguousSecretNames := []string{}
 
 		for _, secretKeyFromArg := range args {
-			if value, ok := secretByKey[strings.ToUpper(secretKeyFromArg)]; ok {
-				validSecretIdsToDelete = append(validSecretIdsToDelete, value.ID)
-			} else {
-				invalidSecretNamesThatDoNotExist = append(invalidSecretNamesThatDoNotExist, secretKeyFromArg)
+			key := strings.ToUpper(secretKeyFromArg)
+			existingSecret, err := resolveUniqueSecretByKey(secretByKey, key)
+			if err != nil {
+				if len(secretByKey[key]) > 1 {
+					ambiguousSecretNames = append(ambiguousSecretNames, secretKeyFromArg)
+				} else {
+					invalidSecretNamesThatDoNotExist = append(invalidSecretNamesThatDoNotExist, secretKeyFromArg)
+				}
+				continue
 			}
+
+			secretsToDelete = append(secretsToDelete, existingSecret)
 		}
 
 		if len(invalidSecretNamesThatDoNotExist) != 0 {
@@ -306,22 +436,53 @@ var secretsDeleteCmd = &cobra.Command{
 			util.PrintMessageAndExit(message)
 		}
 
-		request := api.BatchDeleteSecretsBySecretIdsRequest{
-			WorkspaceId:     workspaceFile.WorkspaceId,
-			EnvironmentName: environmentName,
-			SecretIds:       validSecretIdsToDelete,
+		if len(ambiguousSecretNames) != 0 {
+			message := fmt.Sprintf("secret name(s) [%v] exist in more than one folder under --recursive, narrow down with --path to target a single one", strings.Join(ambiguousSecretNames, ", "))
+			util.PrintMessageAndExit(message)
+		}
+
+		// de-dupe in case a key was named explicitly and also matched by
+		// --tags/--filter, and group by folder since a batch delete request
+		// is scoped to one SecretPath
+		secretIdsByPath := make(map[string][]string)
+		seenIds := make(map[string]bool)
+		deletedNames := []string{}
+
+		for _, secret := range secretsToDelete {
+			if seenIds[secret.ID] {
+				continue
+			}
+			seenIds[secret.ID] = true
+
+			secretIdsByPath[secret.Path] = append(secretIdsByPath[secret.Path], secret.ID)
+			deletedNames = append(deletedNames, secret.Key)
 		}
 
 		httpClient := resty.New().
 			SetAuthToken(loggedInUserDetails.UserCredentials.JTWToken).
 			SetHeader("Accept", "application/json")
 
-		err = api.CallBatchDeleteSecretsByWorkspaceAndEnv(httpClient, request)
-		if err != nil {
-			util.HandleError(err, "Unable to complete your batch delete request")
+		deletePaths := make([]string, 0, len(secretIdsByPath))
+		for path := range secretIdsByPath {
+			deletePaths = append(deletePaths, path)
+		}
+		sort.Strings(deletePaths)
+
+		for _, path := range deletePaths {
+			request := api.BatchDeleteSecretsBySecretIdsRequest{
+				WorkspaceId:     workspaceFile.WorkspaceId,
+				EnvironmentName: environmentName,
+				SecretPath:      path,
+				SecretIds:       secretIdsByPath[path],
+			}
+
+			err = api.CallBatchDeleteSecretsByWorkspaceAndEnv(httpClient, request)
+			if err != nil {
+				util.HandleError(err, "Unable to complete your batch delete request")
+			}
 		}
 
-		fmt.Printf("secret name(s) [%v] have been deleted from your project \n", strings.Join(args, ", "))
+		fmt.Printf("secret name(s) [%v] have been deleted from your project \n", strings.Join(deletedNames, ", "))
 
 	},
 }
@@ -342,31 +503,62 @@ func getSecretsByNames(cmd *cobra.Command, args []string) {
 		util.HandleError(err, "Unable to parse flag")
 	}
 
-	secrets, err := util.GetAllEnvironmentVariables(models.GetAllSecretsParameters{Environment: environmentName, InfisicalToken: infisicalToken})
+	secretsPath, err := cmd.Flags().GetString("path")
 	if err != nil {
-		util.HandleError(err, "To fetch all secrets")
+		util.HandleError(err, "Unable to parse path flag")
 	}
 
-	requestedSecrets := []models.SingleEnvironmentVariable{}
+	shouldRecurse, err := cmd.Flags().GetBool("recursive")
+	if err != nil {
+		util.HandleError(err, "Unable to parse recursive flag")
+	}
 
-	secretsMap := make(map[string]models.SingleEnvironmentVariable)
-	for _, secret := range secrets {
-		secretsMap[secret.Key] = secret
+	secrets, err := util.GetAllEnvironmentVariables(models.GetAllSecretsParameters{Environment: environmentName, InfisicalToken: infisicalToken, SecretsPath: secretsPath, Recursive: shouldRecurse})
+	if err != nil {
+		util.HandleError(err, "To fetch all secrets")
 	}
 
+	requestedSecrets := []models.SingleEnvironmentVariable{}
+
+	secretsByKey := getSecretsByKeys(secrets)
 	for _, secretKeyFromArg := range args {
-		if value, ok := secretsMap[strings.ToUpper(secretKeyFromArg)]; ok {
-			requestedSecrets = append(requestedSecrets, value)
-		} else {
+		existingSecret, err := resolveUniqueSecretByKey(secretsByKey, strings.ToUpper(secretKeyFromArg))
+		if err != nil {
+			if len(secretsByKey[strings.ToUpper(secretKeyFromArg)]) > 1 {
+				util.HandleError(err, "Unable to determine which secret to return")
+			}
 			requestedSecrets = append(requestedSecrets, models.SingleEnvironmentVariable{
 				Key:   secretKeyFromArg,
 				Type:  "*not found*",
 				Value: "*not found*",
 			})
+			continue
 		}
+
+		requestedSecrets = append(requestedSecrets, existingSecret)
+	}
+
+	tags, err := cmd.Flags().GetString("tags")
+	if err != nil {
+		util.HandleError(err, "Unable to parse tags flag")
 	}
 
-	visualize.PrintAllSecretDetails(requestedSecrets)
+	filter, err := cmd.Flags().GetString("filter")
+	if err != nil {
+		util.HandleError(err, "Unable to parse filter flag")
+	}
+
+	requestedSecrets, err = filterSecrets(requestedSecrets, tags, filter)
+	if err != nil {
+		util.HandleError(err, "Unable to apply tags/filter flags")
+	}
+
+	output, err := cmd.Flags().GetString("output")
+	if err != nil {
+		util.HandleError(err, "Unable to parse output flag")
+	}
+
+	printSecretsWithOutput(requestedSecrets, output)
 }
 
 func generateExampleEnv(cmd *cobra.Command, args []string) {
@@ -385,7 +577,17 @@ func generateExampleEnv(cmd *cobra.Command, args []string) {
 		util.HandleError(err, "Unable to parse flag")
 	}
 
-	secrets, err := util.GetAllEnvironmentVariables(models.GetAllSecretsParameters{Environment: environmentName, InfisicalToken: infisicalToken})
+	secretsPath, err := cmd.Flags().GetString("path")
+	if err != nil {
+		util.HandleError(err, "Unable to parse path flag")
+	}
+
+	shouldRecurse, err := cmd.Flags().GetBool("recursive")
+	if err != nil {
+		util.HandleError(err, "Unable to parse recursive flag")
+	}
+
+	secrets, err := util.GetAllEnvironmentVariables(models.GetAllSecretsParameters{Environment: environmentName, InfisicalToken: infisicalToken, SecretsPath: secretsPath, Recursive: shouldRecurse})
 	if err != nil {
 		util.HandleError(err, "To fetch all secrets")
 	}
@@ -393,6 +595,7 @@ func generateExampleEnv(cmd *cobra.Command, args []string) {
 	tagsHashToSecretKey := make(map[string]int)
 
 	type TagsAndSecrets struct {
+		Folder  string
 		Secrets []models.SingleEnvironmentVariable
 		Tags    []struct {
 			ID        string `json:"_id"`
@@ -408,7 +611,7 @@ func generateExampleEnv(cmd *cobra.Command, args []string) {
 	})
 
 	for _, secret := range secrets {
-		listOfTagSlugs := []string{}
+		listOfTagSlugs := []string{secret.Path}
 
 		for _, tag := range secret.Tags {
 			listOfTagSlugs = append(listOfTagSlugs, tag.Slug)
@@ -423,7 +626,7 @@ func generateExampleEnv(cmd *cobra.Command, args []string) {
 	finalTagHashToSecretKey := make(map[string]TagsAndSecrets)
 
 	for _, secret := range secrets {
-		listOfTagSlugs := []string{}
+		listOfTagSlugs := []string{secret.Path}
 		for _, tag := range secret.Tags {
 			listOfTagSlugs = append(listOfTagSlugs, tag.Slug)
 		}
@@ -445,11 +648,13 @@ func generateExampleEnv(cmd *cobra.Command, args []string) {
 
 			if exists2 {
 				finalTagHashToSecretKey[tagsHash] = TagsAndSecrets{
+					Folder:  secret.Path,
 					Tags:    secret.Tags,
 					Secrets: allSecretsForTags,
 				}
 			} else {
 				finalTagHashToSecretKey[tagsHash] = TagsAndSecrets{
+					Folder:  secret.Path,
 					Tags:    secret.Tags,
 					Secrets: []models.SingleEnvironmentVariable{secret},
 				}
@@ -507,9 +712,15 @@ func generateExampleEnv(cmd *cobra.Command, args []string) {
 			listOfTagNames = append(listOfTagNames, tag.Name)
 		}
 
-		heading := CenterString(strings.Join(listOfTagNames, " & "), 80)
+		headingParts := []string{}
+		if secretDetails.Folder != "" && secretDetails.Folder != "/" {
+			headingParts = append(headingParts, secretDetails.Folder)
+		}
+		headingParts = append(headingParts, listOfTagNames...)
+
+		heading := CenterString(strings.Join(headingParts, " & "), 80)
 
-		if len(listOfTagNames) == 0 {
+		if len(headingParts) == 0 {
 			fmt.Printf("\n%s \n", strings.Join(listOfKeyValue, "\n \n"))
 		} else {
 			fmt.Printf("\n\n\n%s\n \n%s \n", heading, strings.Join(listOfKeyValue, "\n \n"))
@@ -534,16 +745,41 @@ func addHash(input string) string {
 	return strings.Join(lines, "\n")
 }
 
-func getSecretsByKeys(secrets []models.SingleEnvironmentVariable) map[string]models.SingleEnvironmentVariable {
-	secretMapByName := make(map[string]models.SingleEnvironmentVariable)
+// getSecretsByKeys groups secrets by Key. Under --recursive the same key can
+// legitimately exist in more than one folder, so callers that need a single
+// secret for a key must go through resolveUniqueSecretByKey rather than
+// picking an arbitrary entry out of the slice.
+func getSecretsByKeys(secrets []models.SingleEnvironmentVariable) map[string][]models.SingleEnvironmentVariable {
+	secretMapByName := make(map[string][]models.SingleEnvironmentVariable)
 
 	for _, secret := range secrets {
-		secretMapByName[secret.Key] = secret
+		secretMapByName[secret.Key] = append(secretMapByName[secret.Key], secret)
 	}
 
 	return secretMapByName
 }
 
+// resolveUniqueSecretByKey looks up the secret(s) sharing the given key and
+// errors if the key doesn't exist or if it's ambiguous, i.e. --recursive
+// turned up the same key in more than one folder. Callers should have the
+// user narrow --path rather than silently acting on one of the matches.
+func resolveUniqueSecretByKey(secretsByKey map[string][]models.SingleEnvironmentVariable, key string) (models.SingleEnvironmentVariable, error) {
+	matches := secretsByKey[key]
+
+	switch len(matches) {
+	case 0:
+		return models.SingleEnvironmentVariable{}, fmt.Errorf("secret %q does not exist", key)
+	case 1:
+		return matches[0], nil
+	default:
+		paths := make([]string, 0, len(matches))
+		for _, match := range matches {
+			paths = append(paths, match.Path)
+		}
+		return models.SingleEnvironmentVariable{}, fmt.Errorf("secret %q exists in more than one folder [%s], narrow down with --path to target a single one", key, strings.Join(paths, ", "))
+	}
+}
+
 func init() {
 
 	secretsGenerateExampleEnvCmd.Flags().String("token", "", "Fetch secrets using the Infisical Token")
@@ -566,6 +802,12 @@ func init() {
 
 	secretsCmd.Flags().String("token", "", "Fetch secrets using the Infisical Token")
 	secretsCmd.PersistentFlags().String("env", "dev", "Used to select the environment name on which actions should be taken on")
+	secretsCmd.PersistentFlags().String("path", "/", "Used to select the secret folder path on which actions should be taken on, e.g. /db/prod")
+	secretsCmd.PersistentFlags().Bool("recursive", false, "Fetch/modify secrets in the given --path and all of its sub folders")
+	secretsCmd.PersistentFlags().String("tags", "", "Only act on secrets carrying one of these comma separated tag slugs/names, e.g. --tags billing,infra")
+	secretsCmd.PersistentFlags().String("filter", "", "Only act on secrets matching a field=REGEX expression, e.g. --filter key=^DB_.*")
 	secretsCmd.Flags().Bool("expand", true, "Parse shell parameter expansions in your secrets")
+	secretsCmd.Flags().String("output", "table", "The format to print secrets in: table, json, yaml or dotenv")
+	secretsGetCmd.Flags().String("output", "table", "The format to print secrets in: table, json, yaml or dotenv")
 	rootCmd.AddCommand(secretsCmd)
 }